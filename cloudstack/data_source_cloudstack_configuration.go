@@ -0,0 +1,102 @@
+package cloudstack
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudstackConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudstackConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"storage_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_dynamic": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudstackConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	c, err := getConfiguration(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		return fmt.Errorf("Configuration setting %s does not exist", name)
+	}
+
+	d.SetId(name)
+	if err := d.Set("value", c.Value); err != nil {
+		return err
+	}
+	if err := d.Set("category", c.Category); err != nil {
+		return err
+	}
+	if err := d.Set("description", c.Description); err != nil {
+		return err
+	}
+	if err := d.Set("scope", c.Scope); err != nil {
+		return err
+	}
+	if err := d.Set("is_dynamic", c.Isdynamic); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package cloudstack
+
+import (
+	"fmt"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudstackLdapUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudstackLdapUsersRead,
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"list_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "all",
+			},
+
+			"user_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"keyword": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"first_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"last_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"principal": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"domain": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"conflicting_user_source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudstackLdapUsersRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	p := cs.LDAP.NewListLdapUsersParams()
+	p.SetListtype(d.Get("list_type").(string))
+
+	if v, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(v.(string))
+	}
+
+	if v, ok := d.GetOk("user_filter"); ok {
+		p.SetUserfilter(v.(string))
+	}
+
+	if v, ok := d.GetOk("keyword"); ok {
+		p.SetKeyword(v.(string))
+	}
+
+	l, err := cs.LDAP.ListLdapUsers(p)
+	if err != nil {
+		return fmt.Errorf("Failed to list LDAP users: %s", err)
+	}
+
+	var users []interface{}
+	for _, u := range l.LdapUsers {
+		users = append(users, map[string]interface{}{
+			"username":                u.Username,
+			"email":                   u.Email,
+			"first_name":              u.Firstname,
+			"last_name":               u.Lastname,
+			"principal":               u.Principal,
+			"domain":                  u.Domain,
+			"conflicting_user_source": u.Conflictingusersource,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("ldap-users-%s-%s", d.Get("domain_id").(string), d.Get("list_type").(string)))
+
+	return d.Set("users", users)
+}
@@ -0,0 +1,133 @@
+package cloudstack
+
+import (
+	"fmt"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCloudstackRouter() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudstackRouterRead,
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"network_id", "vpc_id"},
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"routers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"redundant_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"service_offering_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"template_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"requires_upgrade": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"guest_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"host_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudstackRouterRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	routers, err := listRouters(cs, d)
+	if err != nil {
+		return err
+	}
+
+	if len(routers) == 0 {
+		return fmt.Errorf("No router found for the specified network or VPC")
+	}
+
+	var rs []interface{}
+	for _, r := range routers {
+		rs = append(rs, map[string]interface{}{
+			"id":                  r.Id,
+			"name":                r.Name,
+			"state":               r.State,
+			"redundant_state":     r.Redundantstate,
+			"service_offering_id": r.Serviceofferingid,
+			"template_id":         r.Templateid,
+			"version":             r.Version,
+			"requires_upgrade":    r.Requiresupgrade,
+			"public_ip":           r.Publicip,
+			"guest_ip_address":    r.Guestipaddress,
+			"host_id":             r.Hostid,
+		})
+	}
+
+	if v, ok := d.GetOk("network_id"); ok {
+		d.SetId(v.(string))
+	} else {
+		d.SetId(d.Get("vpc_id").(string))
+	}
+
+	return d.Set("routers", rs)
+}
@@ -0,0 +1,69 @@
+package cloudstack
+
+import (
+	"fmt"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dedicatedSchema returns the schema of a dedicated host, cluster or pod,
+// where key is the attribute holding the ID of the dedicated entity
+func dedicatedSchema(key string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		key: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"domain_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"account": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+
+		"affinity_group_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// setDedicatedOwner sets the domain and account a host, cluster or pod is
+// dedicated to. The API only returns the account ID, so look up its name.
+func setDedicatedOwner(cs *cloudstack.CloudStackClient, d *schema.ResourceData, domainid, accountid, affinitygroupid string) error {
+	account := ""
+	if accountid != "" {
+		p := cs.Account.NewListAccountsParams()
+		p.SetId(accountid)
+		p.SetDomainid(domainid)
+		p.SetListall(true)
+
+		l, err := cs.Account.ListAccounts(p)
+		if err != nil {
+			return fmt.Errorf("Error retrieving account %s: %s", accountid, err)
+		}
+
+		if l.Count != 1 {
+			return fmt.Errorf("Account %s not found", accountid)
+		}
+
+		account = l.Accounts[0].Name
+	}
+
+	if err := d.Set("domain_id", domainid); err != nil {
+		return err
+	}
+	if err := d.Set("account", account); err != nil {
+		return err
+	}
+
+	return d.Set("affinity_group_id", affinitygroupid)
+}
@@ -0,0 +1,108 @@
+package cloudstack
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const keybaseLookupURL = "https://keybase.io/_/api/1.0/user/lookup.json"
+
+// encryptPassword encrypts the password with the given PGP key and returns
+// the encrypted message base64 encoded. The key is either a base64 encoded
+// public key or a keybase username prefixed with "keybase:".
+func encryptPassword(pgpKey string, password string) (string, error) {
+	entities, err := retrievePGPKey(pgpKey)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := openpgp.Encrypt(&buf, entities, nil, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("Error encrypting password: %s", err)
+	}
+
+	if _, err := w.Write([]byte(password)); err != nil {
+		return "", fmt.Errorf("Error encrypting password: %s", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("Error encrypting password: %s", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// retrievePGPKey parses the given PGP key, fetching it from keybase if needed
+func retrievePGPKey(pgpKey string) (openpgp.EntityList, error) {
+	if strings.HasPrefix(pgpKey, "keybase:") {
+		bundle, err := retrieveKeybaseKey(strings.TrimPrefix(pgpKey, "keybase:"))
+		if err != nil {
+			return nil, err
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(bundle))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing PGP key of %s: %s", pgpKey, err)
+		}
+
+		return entities, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(pgpKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding PGP key, the key must be base64 encoded: %s", err)
+	}
+
+	entities, err := openpgp.ReadKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing PGP key: %s", err)
+	}
+
+	return entities, nil
+}
+
+// retrieveKeybaseKey returns the armored primary public key of a keybase user
+func retrieveKeybaseKey(username string) (string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	resp, err := client.Get(fmt.Sprintf(
+		"%s?usernames=%s&fields=public_keys", keybaseLookupURL, url.QueryEscape(username)))
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving PGP key of keybase user %s: %s", username, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf(
+			"Error retrieving PGP key of keybase user %s: %s", username, resp.Status)
+	}
+
+	var lookup struct {
+		Them []struct {
+			PublicKeys struct {
+				Primary struct {
+					Bundle string `json:"bundle"`
+				} `json:"primary"`
+			} `json:"public_keys"`
+		} `json:"them"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&lookup); err != nil {
+		return "", fmt.Errorf("Error decoding keybase response for %s: %s", username, err)
+	}
+
+	if len(lookup.Them) != 1 || lookup.Them[0].PublicKeys.Primary.Bundle == "" {
+		return "", fmt.Errorf("No PGP key found for keybase user %s", username)
+	}
+
+	return lookup.Them[0].PublicKeys.Primary.Bundle, nil
+}
@@ -0,0 +1,56 @@
+package cloudstack
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+func TestEncryptPassword(t *testing.T) {
+	entity, err := openpgp.NewEntity("terraform", "test", "terraform@example.com", nil)
+	if err != nil {
+		t.Fatalf("Error generating PGP key: %s", err)
+	}
+
+	var key bytes.Buffer
+	if err := entity.Serialize(&key); err != nil {
+		t.Fatalf("Error serializing PGP key: %s", err)
+	}
+
+	encrypted, err := encryptPassword(base64.StdEncoding.EncodeToString(key.Bytes()), "secret")
+	if err != nil {
+		t.Fatalf("Error encrypting password: %s", err)
+	}
+
+	message, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("Error decoding encrypted password: %s", err)
+	}
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(message), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatalf("Error decrypting password: %s", err)
+	}
+
+	password, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		t.Fatalf("Error reading decrypted password: %s", err)
+	}
+
+	if string(password) != "secret" {
+		t.Fatalf("bad password: %q", password)
+	}
+}
+
+func TestEncryptPassword_invalidKey(t *testing.T) {
+	if _, err := encryptPassword("not a key", "secret"); err == nil {
+		t.Fatal("expected an error for a key that is not base64 encoded")
+	}
+
+	if _, err := encryptPassword(base64.StdEncoding.EncodeToString([]byte("foo")), "secret"); err == nil {
+		t.Fatal("expected an error for an invalid PGP key")
+	}
+}
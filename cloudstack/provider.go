@@ -58,35 +58,67 @@ func Provider() *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"cloudstack_template": dataSourceCloudstackTemplate(),
+			"cloudstack_configuration": dataSourceCloudstackConfiguration(),
+			"cloudstack_ldap_users":    dataSourceCloudstackLdapUsers(),
+			"cloudstack_router":        dataSourceCloudstackRouter(),
+			"cloudstack_template":      dataSourceCloudstackTemplate(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"cloudstack_affinity_group":       resourceCloudStackAffinityGroup(),
-			"cloudstack_autoscale_vm_profile": resourceCloudStackAutoScaleVMProfile(),
-			"cloudstack_disk":                 resourceCloudStackDisk(),
-			"cloudstack_egress_firewall":      resourceCloudStackEgressFirewall(),
-			"cloudstack_firewall":             resourceCloudStackFirewall(),
-			"cloudstack_instance":             resourceCloudStackInstance(),
-			"cloudstack_ipaddress":            resourceCloudStackIPAddress(),
-			"cloudstack_loadbalancer_rule":    resourceCloudStackLoadBalancerRule(),
-			"cloudstack_network":              resourceCloudStackNetwork(),
-			"cloudstack_network_acl":          resourceCloudStackNetworkACL(),
-			"cloudstack_network_acl_rule":     resourceCloudStackNetworkACLRule(),
-			"cloudstack_nic":                  resourceCloudStackNIC(),
-			"cloudstack_port_forward":         resourceCloudStackPortForward(),
-			"cloudstack_private_gateway":      resourceCloudStackPrivateGateway(),
-			"cloudstack_secondary_ipaddress":  resourceCloudStackSecondaryIPAddress(),
-			"cloudstack_security_group":       resourceCloudStackSecurityGroup(),
-			"cloudstack_security_group_rule":  resourceCloudStackSecurityGroupRule(),
-			"cloudstack_ssh_keypair":          resourceCloudStackSSHKeyPair(),
-			"cloudstack_static_nat":           resourceCloudStackStaticNAT(),
-			"cloudstack_static_route":         resourceCloudStackStaticRoute(),
-			"cloudstack_template":             resourceCloudStackTemplate(),
-			"cloudstack_vpc":                  resourceCloudStackVPC(),
-			"cloudstack_vpn_connection":       resourceCloudStackVPNConnection(),
-			"cloudstack_vpn_customer_gateway": resourceCloudStackVPNCustomerGateway(),
-			"cloudstack_vpn_gateway":          resourceCloudStackVPNGateway(),
+			"cloudstack_affinity_group":                resourceCloudStackAffinityGroup(),
+			"cloudstack_autoscale_vm_profile":          resourceCloudStackAutoScaleVMProfile(),
+			"cloudstack_cluster":                       resourceCloudStackCluster(),
+			"cloudstack_configuration":                 resourceCloudStackConfiguration(),
+			"cloudstack_dedicated_cluster":             resourceCloudStackDedicatedCluster(),
+			"cloudstack_dedicated_guest_vlan_range":    resourceCloudStackDedicatedGuestVLANRange(),
+			"cloudstack_dedicated_host":                resourceCloudStackDedicatedHost(),
+			"cloudstack_dedicated_pod":                 resourceCloudStackDedicatedPod(),
+			"cloudstack_disk":                          resourceCloudStackDisk(),
+			"cloudstack_egress_firewall":               resourceCloudStackEgressFirewall(),
+			"cloudstack_firewall":                      resourceCloudStackFirewall(),
+			"cloudstack_global_loadbalancer_rule":      resourceCloudStackGlobalLoadBalancerRule(),
+			"cloudstack_host":                          resourceCloudStackHost(),
+			"cloudstack_image_store":                   resourceCloudStackImageStore(),
+			"cloudstack_instance":                      resourceCloudStackInstance(),
+			"cloudstack_instance_group":                resourceCloudStackInstanceGroup(),
+			"cloudstack_internal_loadbalancer":         resourceCloudStackInternalLoadBalancer(),
+			"cloudstack_internal_loadbalancer_element": resourceCloudStackInternalLoadBalancerElement(),
+			"cloudstack_ipaddress":                     resourceCloudStackIPAddress(),
+			"cloudstack_iso":                           resourceCloudStackISO(),
+			"cloudstack_iso_attachment":                resourceCloudStackISOAttachment(),
+			"cloudstack_kubernetes_cluster":            resourceCloudStackKubernetesCluster(),
+			"cloudstack_kubernetes_version":            resourceCloudStackKubernetesVersion(),
+			"cloudstack_ldap_configuration":            resourceCloudStackLdapConfiguration(),
+			"cloudstack_ldap_domain_link":              resourceCloudStackLdapDomainLink(),
+			"cloudstack_loadbalancer_rule":             resourceCloudStackLoadBalancerRule(),
+			"cloudstack_network":                       resourceCloudStackNetwork(),
+			"cloudstack_network_acl":                   resourceCloudStackNetworkACL(),
+			"cloudstack_network_acl_rule":              resourceCloudStackNetworkACLRule(),
+			"cloudstack_nic":                           resourceCloudStackNIC(),
+			"cloudstack_physical_network":              resourceCloudStackPhysicalNetwork(),
+			"cloudstack_pod":                           resourceCloudStackPod(),
+			"cloudstack_port_forward":                  resourceCloudStackPortForward(),
+			"cloudstack_portable_ip_range":             resourceCloudStackPortableIPRange(),
+			"cloudstack_private_gateway":               resourceCloudStackPrivateGateway(),
+			"cloudstack_remote_access_vpn":             resourceCloudStackRemoteAccessVPN(),
+			"cloudstack_resource_limit":                resourceCloudStackResourceLimit(),
+			"cloudstack_router_settings":               resourceCloudStackRouterSettings(),
+			"cloudstack_secondary_ipaddress":           resourceCloudStackSecondaryIPAddress(),
+			"cloudstack_security_group":                resourceCloudStackSecurityGroup(),
+			"cloudstack_security_group_rule":           resourceCloudStackSecurityGroupRule(),
+			"cloudstack_ssh_keypair":                   resourceCloudStackSSHKeyPair(),
+			"cloudstack_ssl_certificate":               resourceCloudStackSSLCertificate(),
+			"cloudstack_static_nat":                    resourceCloudStackStaticNAT(),
+			"cloudstack_static_route":                  resourceCloudStackStaticRoute(),
+			"cloudstack_storage_pool":                  resourceCloudStackStoragePool(),
+			"cloudstack_template":                      resourceCloudStackTemplate(),
+			"cloudstack_vlan_ip_range":                 resourceCloudStackVLANIPRange(),
+			"cloudstack_vpc":                           resourceCloudStackVPC(),
+			"cloudstack_vpn_connection":                resourceCloudStackVPNConnection(),
+			"cloudstack_vpn_customer_gateway":          resourceCloudStackVPNCustomerGateway(),
+			"cloudstack_vpn_gateway":                   resourceCloudStackVPNGateway(),
+			"cloudstack_vpn_user":                      resourceCloudStackVPNUser(),
+			"cloudstack_zone":                          resourceCloudStackZone(),
 		},
 
 		ConfigureFunc: providerConfigure,
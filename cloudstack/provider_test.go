@@ -62,3 +62,15 @@ func testAccPreCheck(t *testing.T) {
 }
 
 var CLOUDSTACK_TEMPLATE_URL = os.Getenv("CLOUDSTACK_TEMPLATE_URL")
+
+var CLOUDSTACK_KUBERNETES_ISO_URL = os.Getenv("CLOUDSTACK_KUBERNETES_ISO_URL")
+
+var CLOUDSTACK_INTERNAL_LB_NSP_ID = os.Getenv("CLOUDSTACK_INTERNAL_LB_NSP_ID")
+
+var CLOUDSTACK_ISO_URL = os.Getenv("CLOUDSTACK_ISO_URL")
+
+var CLOUDSTACK_PHYSICAL_NETWORK_ID = os.Getenv("CLOUDSTACK_PHYSICAL_NETWORK_ID")
+
+var CLOUDSTACK_DOMAIN_ID = os.Getenv("CLOUDSTACK_DOMAIN_ID")
+
+var CLOUDSTACK_SECURITY_GROUP_ZONE = os.Getenv("CLOUDSTACK_SECURITY_GROUP_ZONE")
@@ -0,0 +1,241 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackClusterCreate,
+		Read:   resourceCloudStackClusterRead,
+		Update: resourceCloudStackClusterUpdate,
+		Delete: resourceCloudStackClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"cluster_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "CloudManaged",
+				ForceNew: true,
+			},
+
+			"hypervisor": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"pod_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				ForceNew:  true,
+			},
+
+			"allocation_state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"managed_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyClusterParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewAddClusterParams(
+		name,
+		d.Get("cluster_type").(string),
+		d.Get("hypervisor").(string),
+		d.Get("pod_id").(string),
+		zoneid,
+	)
+
+	if v, ok := d.GetOk("url"); ok {
+		p.SetUrl(v.(string))
+	}
+
+	if v, ok := d.GetOk("username"); ok {
+		p.SetUsername(v.(string))
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		p.SetPassword(v.(string))
+	}
+
+	if v, ok := d.GetOk("allocation_state"); ok {
+		p.SetAllocationstate(v.(string))
+	}
+
+	// Add the new cluster
+	r, err := cs.Cluster.AddCluster(p)
+	if err != nil {
+		return fmt.Errorf("Error adding cluster %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackClusterRead(d, meta)
+}
+
+func resourceCloudStackClusterRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the cluster details
+	c, count, err := cs.Cluster.GetClusterByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Cluster %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", c.Name); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_type", c.Clustertype); err != nil {
+		return err
+	}
+	if err := d.Set("hypervisor", c.Hypervisortype); err != nil {
+		return err
+	}
+	if err := d.Set("pod_id", c.Podid); err != nil {
+		return err
+	}
+	if err := d.Set("allocation_state", c.Allocationstate); err != nil {
+		return err
+	}
+	if err := d.Set("managed_state", c.Managedstate); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "zone", c.Zonename, c.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyClusterParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewUpdateClusterParams(d.Id())
+
+	if d.HasChange("name") {
+		p.SetClustername(name)
+	}
+
+	if d.HasChange("allocation_state") {
+		p.SetAllocationstate(d.Get("allocation_state").(string))
+	}
+
+	log.Printf("[DEBUG] Updating cluster %s", name)
+	if _, err := cs.Cluster.UpdateCluster(p); err != nil {
+		return fmt.Errorf("Error updating cluster %s: %s", name, err)
+	}
+
+	return resourceCloudStackClusterRead(d, meta)
+}
+
+func resourceCloudStackClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewDeleteClusterParams(d.Id())
+
+	// Delete the cluster
+	log.Printf("[INFO] Deleting cluster: %s", d.Get("name").(string))
+	_, err := cs.Cluster.DeleteCluster(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func verifyClusterParams(d *schema.ResourceData) error {
+	clusterType := d.Get("cluster_type").(string)
+	if clusterType != "CloudManaged" && clusterType != "ExternalManaged" {
+		return fmt.Errorf(
+			"%q is not a valid cluster type. Valid options are 'CloudManaged' and 'ExternalManaged'",
+			clusterType)
+	}
+
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	return nil
+}
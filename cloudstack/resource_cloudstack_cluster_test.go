@@ -0,0 +1,118 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackCluster_basic(t *testing.T) {
+	var cluster cloudstack.Cluster
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackCluster_basic("Enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackClusterExists("cloudstack_cluster.foo", &cluster),
+					resource.TestCheckResourceAttr(
+						"cloudstack_cluster.foo", "hypervisor", "Simulator"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_cluster.foo", "allocation_state", "Enabled"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackCluster_basic("Disabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackClusterExists("cloudstack_cluster.foo", &cluster),
+					resource.TestCheckResourceAttr(
+						"cloudstack_cluster.foo", "allocation_state", "Disabled"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackClusterExists(
+	n string, cluster *cloudstack.Cluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No cluster ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		c, _, err := cs.Cluster.GetClusterByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if c.Id != rs.Primary.ID {
+			return fmt.Errorf("Cluster not found")
+		}
+
+		*cluster = *c
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackClusterDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_cluster" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No cluster ID is set")
+		}
+
+		_, _, err := cs.Cluster.GetClusterByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Cluster %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackCluster_basic(state string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_cluster" "foo" {
+  name = "terraform-cluster"
+  hypervisor = "Simulator"
+  pod_id = cloudstack_pod.foo.id
+  zone = cloudstack_zone.foo.name
+  allocation_state = "%s"
+}`, state)
+}
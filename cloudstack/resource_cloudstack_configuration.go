@@ -0,0 +1,253 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configurationScopes lists the attributes that scope a configuration setting
+var configurationScopes = []string{"zone", "cluster_id", "storage_id", "account_id", "domain_id"}
+
+func resourceCloudStackConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackConfigurationCreate,
+		Read:   resourceCloudStackConfigurationRead,
+		Update: resourceCloudStackConfigurationUpdate,
+		Delete: resourceCloudStackConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"zone": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cluster_id", "storage_id", "account_id", "domain_id"},
+			},
+
+			"cluster_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone", "storage_id", "account_id", "domain_id"},
+			},
+
+			"storage_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone", "cluster_id", "account_id", "domain_id"},
+			},
+
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone", "cluster_id", "storage_id", "domain_id"},
+			},
+
+			"domain_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"zone", "cluster_id", "storage_id", "account_id"},
+			},
+
+			"previous_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_dynamic": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+
+	// Record the current value, so it can be restored on destroy
+	c, err := getConfiguration(d, meta)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return fmt.Errorf("Configuration setting %s does not exist", name)
+	}
+
+	if err := d.Set("previous_value", c.Value); err != nil {
+		return err
+	}
+
+	if err := updateConfiguration(d, meta, d.Get("value").(string)); err != nil {
+		return err
+	}
+
+	// Build a unique ID from the name and the scope of the setting
+	id := name
+	for _, scope := range configurationScopes {
+		if v, ok := d.GetOk(scope); ok {
+			id = fmt.Sprintf("%s/%s", v.(string), name)
+		}
+	}
+	d.SetId(id)
+
+	return resourceCloudStackConfigurationRead(d, meta)
+}
+
+func resourceCloudStackConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	c, err := getConfiguration(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		log.Printf("[DEBUG] Configuration setting %s does no longer exist", d.Get("name").(string))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("value", c.Value); err != nil {
+		return err
+	}
+	if err := d.Set("category", c.Category); err != nil {
+		return err
+	}
+	if err := d.Set("description", c.Description); err != nil {
+		return err
+	}
+	if err := d.Set("is_dynamic", c.Isdynamic); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("value") {
+		if err := updateConfiguration(d, meta, d.Get("value").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackConfigurationRead(d, meta)
+}
+
+func resourceCloudStackConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	// Settings cannot be deleted, so restore the value from before we managed it
+	log.Printf("[INFO] Restoring configuration setting %s to its previous value", d.Get("name").(string))
+	return updateConfiguration(d, meta, d.Get("previous_value").(string))
+}
+
+// configurationScopeSetter is implemented by the parameter structs that
+// support scoping a configuration setting
+type configurationScopeSetter interface {
+	SetAccountid(string)
+	SetClusterid(string)
+	SetDomainid(string)
+	SetStorageid(string)
+	SetZoneid(string)
+}
+
+func setConfigurationScope(p configurationScopeSetter, cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	if zone, ok := d.GetOk("zone"); ok {
+		zoneid, e := retrieveID(cs, "zone", zone.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetZoneid(zoneid)
+	}
+
+	if clusterid, ok := d.GetOk("cluster_id"); ok {
+		p.SetClusterid(clusterid.(string))
+	}
+
+	if storageid, ok := d.GetOk("storage_id"); ok {
+		p.SetStorageid(storageid.(string))
+	}
+
+	if accountid, ok := d.GetOk("account_id"); ok {
+		p.SetAccountid(accountid.(string))
+	}
+
+	if domainid, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(domainid.(string))
+	}
+
+	return nil
+}
+
+// getConfiguration returns the configured setting, or nil if it doesn't exist
+func getConfiguration(d *schema.ResourceData, meta interface{}) (*cloudstack.Configuration, error) {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Configuration.NewListConfigurationsParams()
+	p.SetName(name)
+
+	if err := setConfigurationScope(p, cs, d); err != nil {
+		return nil, err
+	}
+
+	l, err := cs.Configuration.ListConfigurations(p)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving configuration setting %s: %s", name, err)
+	}
+
+	// The name is matched as a substring, so look for an exact match
+	for _, c := range l.Configurations {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func updateConfiguration(d *schema.ResourceData, meta interface{}, value string) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Configuration.NewUpdateConfigurationParams(name)
+	p.SetValue(value)
+
+	if err := setConfigurationScope(p, cs, d); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Setting configuration setting %s to %q", name, value)
+	if _, err := cs.Configuration.UpdateConfiguration(p); err != nil {
+		return fmt.Errorf("Error updating configuration setting %s: %s", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackConfiguration_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackConfiguration_basic("10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackConfigurationValue("cloudstack_configuration.foo", "10"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_configuration.foo", "value", "10"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_configuration.foo", "previous_value"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackConfiguration_basic("12"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackConfigurationValue("cloudstack_configuration.foo", "12"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_configuration.foo", "value", "12"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackConfiguration_zone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackConfiguration_zone,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackConfigurationValue("cloudstack_configuration.foo", "false"),
+					resource.TestCheckResourceAttrPair(
+						"data.cloudstack_configuration.foo", "value",
+						"cloudstack_configuration.foo", "value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackConfigurationValue(n string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No configuration ID is set")
+		}
+
+		c, err := testAccGetConfiguration(rs)
+		if err != nil {
+			return err
+		}
+
+		if c.Value != value {
+			return fmt.Errorf("Bad value: expected %q, got %q", value, c.Value)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackConfigurationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_configuration" {
+			continue
+		}
+
+		c, err := testAccGetConfiguration(rs)
+		if err != nil {
+			return err
+		}
+
+		if c.Value != rs.Primary.Attributes["previous_value"] {
+			return fmt.Errorf("Configuration setting %s was not restored: %q", c.Name, c.Value)
+		}
+	}
+
+	return nil
+}
+
+func testAccGetConfiguration(rs *terraform.ResourceState) (*cloudstack.Configuration, error) {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	name := rs.Primary.Attributes["name"]
+
+	p := cs.Configuration.NewListConfigurationsParams()
+	p.SetName(name)
+
+	if zone := rs.Primary.Attributes["zone"]; zone != "" {
+		zoneid, e := retrieveID(cs, "zone", zone)
+		if e != nil {
+			return nil, e.Error()
+		}
+		p.SetZoneid(zoneid)
+	}
+
+	l, err := cs.Configuration.ListConfigurations(p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range l.Configurations {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Configuration setting %s not found", name)
+}
+
+func testAccCloudStackConfiguration_basic(value string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_configuration" "foo" {
+  name = "vm.password.length"
+  value = "%s"
+}`, value)
+}
+
+const testAccCloudStackConfiguration_zone = `
+resource "cloudstack_configuration" "foo" {
+  name = "use.external.dns"
+  value = "false"
+  zone = "Sandbox-simulator"
+}
+
+data "cloudstack_configuration" "foo" {
+  name = "${cloudstack_configuration.foo.name}"
+  zone = "${cloudstack_configuration.foo.zone}"
+}`
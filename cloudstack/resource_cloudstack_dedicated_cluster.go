@@ -0,0 +1,98 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackDedicatedCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackDedicatedClusterCreate,
+		Read:   resourceCloudStackDedicatedClusterRead,
+		Delete: resourceCloudStackDedicatedClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: dedicatedSchema("cluster_id"),
+	}
+}
+
+func resourceCloudStackDedicatedClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	clusterid := d.Get("cluster_id").(string)
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewDedicateClusterParams(clusterid, d.Get("domain_id").(string))
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// Dedicate the cluster
+	if _, err := cs.Cluster.DedicateCluster(p); err != nil {
+		return fmt.Errorf("Error dedicating cluster %s: %s", clusterid, err)
+	}
+
+	// The dedication is identified by the cluster it applies to
+	d.SetId(clusterid)
+
+	return resourceCloudStackDedicatedClusterRead(d, meta)
+}
+
+func resourceCloudStackDedicatedClusterRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewListDedicatedClustersParams()
+	p.SetClusterid(d.Id())
+
+	// Get the dedication details
+	l, err := cs.Cluster.ListDedicatedClusters(p)
+	if err != nil {
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] Dedication of cluster %s does no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	dedicated := l.DedicatedClusters[0]
+
+	if err := d.Set("cluster_id", dedicated.Clusterid); err != nil {
+		return err
+	}
+
+	return setDedicatedOwner(
+		cs, d, dedicated.Domainid, dedicated.Accountid, dedicated.Affinitygroupid)
+}
+
+func resourceCloudStackDedicatedClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Cluster.NewReleaseDedicatedClusterParams(d.Id())
+
+	// Release the cluster
+	log.Printf("[INFO] Releasing dedicated cluster: %s", d.Id())
+	_, err := cs.Cluster.ReleaseDedicatedCluster(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error releasing dedicated cluster %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackDedicatedCluster_basic(t *testing.T) {
+	if CLOUDSTACK_DOMAIN_ID == "" {
+		t.Skip("This test requires the ID of a domain to dedicate the cluster to")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackDedicatedClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackDedicatedCluster_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackDedicatedClusterExists("cloudstack_dedicated_cluster.foo"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_dedicated_cluster.foo", "domain_id", CLOUDSTACK_DOMAIN_ID),
+				),
+			},
+
+			{
+				ResourceName:      "cloudstack_dedicated_cluster.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackDedicatedClusterExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated cluster ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+		p := cs.Cluster.NewListDedicatedClustersParams()
+		p.SetClusterid(rs.Primary.ID)
+
+		l, err := cs.Cluster.ListDedicatedClusters(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count == 0 {
+			return fmt.Errorf("Dedicated cluster not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackDedicatedClusterDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_dedicated_cluster" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated cluster ID is set")
+		}
+
+		p := cs.Cluster.NewListDedicatedClustersParams()
+		p.SetClusterid(rs.Primary.ID)
+
+		l, err := cs.Cluster.ListDedicatedClusters(p)
+		if err == nil && l.Count > 0 {
+			return fmt.Errorf("Cluster %s is still dedicated", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackDedicatedCluster_basic() string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_cluster" "foo" {
+  name = "terraform-cluster"
+  hypervisor = "Simulator"
+  pod_id = cloudstack_pod.foo.id
+  zone = cloudstack_zone.foo.name
+}
+
+resource "cloudstack_dedicated_cluster" "foo" {
+  cluster_id = cloudstack_cluster.foo.id
+  domain_id = "%s"
+}`, CLOUDSTACK_DOMAIN_ID)
+}
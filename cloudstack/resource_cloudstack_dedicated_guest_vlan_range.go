@@ -0,0 +1,153 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackDedicatedGuestVLANRange() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackDedicatedGuestVLANRangeCreate,
+		Read:   resourceCloudStackDedicatedGuestVLANRangeRead,
+		Delete: resourceCloudStackDedicatedGuestVLANRangeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"physical_network_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan_range": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"account": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"domain_id"},
+				ConflictsWith: []string{"project"},
+			},
+
+			"domain_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"project": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"account"},
+			},
+		},
+	}
+}
+
+func resourceCloudStackDedicatedGuestVLANRangeCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if d.Get("account").(string) == "" && d.Get("project").(string) == "" {
+		return fmt.Errorf("Either an account or a project is required to dedicate a guest VLAN range")
+	}
+
+	vlanRange := d.Get("vlan_range").(string)
+
+	// Create a new parameter struct
+	p := cs.VLAN.NewDedicateGuestVlanRangeParams(
+		d.Get("physical_network_id").(string),
+		vlanRange,
+	)
+
+	if domainid, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(domainid.(string))
+	}
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Dedicate the guest VLAN range
+	r, err := cs.VLAN.DedicateGuestVlanRange(p)
+	if err != nil {
+		return fmt.Errorf("Error dedicating guest VLAN range %s: %s", vlanRange, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackDedicatedGuestVLANRangeRead(d, meta)
+}
+
+func resourceCloudStackDedicatedGuestVLANRangeRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the dedicated guest VLAN range details
+	r, count, err := cs.VLAN.GetDedicatedGuestVlanRangeByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf(
+				"[DEBUG] Dedicated guest VLAN range %s does no longer exist", d.Get("vlan_range").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("vlan_range", r.Guestvlanrange); err != nil {
+		return err
+	}
+	if err := d.Set("domain_id", r.Domainid); err != nil {
+		return err
+	}
+
+	// A range dedicated to a project is owned by the project account
+	if r.Projectid == "" {
+		if err := d.Set("account", r.Account); err != nil {
+			return err
+		}
+	}
+
+	setValueOrID(d, "project", r.Project, r.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackDedicatedGuestVLANRangeDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VLAN.NewReleaseDedicatedGuestVlanRangeParams(d.Id())
+
+	// Release the guest VLAN range
+	log.Printf("[INFO] Releasing dedicated guest VLAN range: %s", d.Get("vlan_range").(string))
+	_, err := cs.VLAN.ReleaseDedicatedGuestVlanRange(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"Error releasing dedicated guest VLAN range %s: %s", d.Get("vlan_range").(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackDedicatedGuestVLANRange_basic(t *testing.T) {
+	if CLOUDSTACK_PHYSICAL_NETWORK_ID == "" {
+		t.Skip("This test requires the ID of a physical network with a guest VLAN range")
+	}
+
+	var vlan cloudstack.DedicatedGuestVlanRange
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackDedicatedGuestVLANRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackDedicatedGuestVLANRange_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackDedicatedGuestVLANRangeExists(
+						"cloudstack_dedicated_guest_vlan_range.foo", &vlan),
+					resource.TestCheckResourceAttr(
+						"cloudstack_dedicated_guest_vlan_range.foo", "project", "terraform"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackDedicatedGuestVLANRangeExists(
+	n string, vlan *cloudstack.DedicatedGuestVlanRange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated guest VLAN range ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		r, _, err := cs.VLAN.GetDedicatedGuestVlanRangeByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if r.Id != rs.Primary.ID {
+			return fmt.Errorf("Dedicated guest VLAN range not found")
+		}
+
+		*vlan = *r
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackDedicatedGuestVLANRangeDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_dedicated_guest_vlan_range" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated guest VLAN range ID is set")
+		}
+
+		_, _, err := cs.VLAN.GetDedicatedGuestVlanRangeByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Dedicated guest VLAN range %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackDedicatedGuestVLANRange_basic() string {
+	return fmt.Sprintf(`
+resource "cloudstack_dedicated_guest_vlan_range" "foo" {
+  physical_network_id = "%s"
+  vlan_range = "310-319"
+  project = "terraform"
+}`, CLOUDSTACK_PHYSICAL_NETWORK_ID)
+}
@@ -0,0 +1,98 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackDedicatedHostCreate,
+		Read:   resourceCloudStackDedicatedHostRead,
+		Delete: resourceCloudStackDedicatedHostDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: dedicatedSchema("host_id"),
+	}
+}
+
+func resourceCloudStackDedicatedHostCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	hostid := d.Get("host_id").(string)
+
+	// Create a new parameter struct
+	p := cs.Host.NewDedicateHostParams(d.Get("domain_id").(string), hostid)
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// Dedicate the host
+	if _, err := cs.Host.DedicateHost(p); err != nil {
+		return fmt.Errorf("Error dedicating host %s: %s", hostid, err)
+	}
+
+	// The dedication is identified by the host it applies to
+	d.SetId(hostid)
+
+	return resourceCloudStackDedicatedHostRead(d, meta)
+}
+
+func resourceCloudStackDedicatedHostRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Host.NewListDedicatedHostsParams()
+	p.SetHostid(d.Id())
+
+	// Get the dedication details
+	l, err := cs.Host.ListDedicatedHosts(p)
+	if err != nil {
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] Dedication of host %s does no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	dedicated := l.DedicatedHosts[0]
+
+	if err := d.Set("host_id", dedicated.Hostid); err != nil {
+		return err
+	}
+
+	return setDedicatedOwner(
+		cs, d, dedicated.Domainid, dedicated.Accountid, dedicated.Affinitygroupid)
+}
+
+func resourceCloudStackDedicatedHostDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Host.NewReleaseDedicatedHostParams(d.Id())
+
+	// Release the host
+	log.Printf("[INFO] Releasing dedicated host: %s", d.Id())
+	_, err := cs.Host.ReleaseDedicatedHost(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error releasing dedicated host %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackDedicatedHost_basic(t *testing.T) {
+	if CLOUDSTACK_DOMAIN_ID == "" {
+		t.Skip("This test requires the ID of a domain to dedicate the host to")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackDedicatedHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackDedicatedHost_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackDedicatedHostExists("cloudstack_dedicated_host.foo"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_dedicated_host.foo", "domain_id", CLOUDSTACK_DOMAIN_ID),
+				),
+			},
+
+			{
+				ResourceName:      "cloudstack_dedicated_host.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackDedicatedHostExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated host ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+		p := cs.Host.NewListDedicatedHostsParams()
+		p.SetHostid(rs.Primary.ID)
+
+		l, err := cs.Host.ListDedicatedHosts(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count == 0 {
+			return fmt.Errorf("Dedicated host not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackDedicatedHostDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_dedicated_host" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated host ID is set")
+		}
+
+		p := cs.Host.NewListDedicatedHostsParams()
+		p.SetHostid(rs.Primary.ID)
+
+		l, err := cs.Host.ListDedicatedHosts(p)
+		if err == nil && l.Count > 0 {
+			return fmt.Errorf("Host %s is still dedicated", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackDedicatedHost_basic() string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_cluster" "foo" {
+  name = "terraform-cluster"
+  hypervisor = "Simulator"
+  pod_id = cloudstack_pod.foo.id
+  zone = cloudstack_zone.foo.name
+}
+
+resource "cloudstack_host" "foo" {
+  hypervisor = "Simulator"
+  url = "http://sim/c0/h9"
+  username = "root"
+  password = "password"
+  pod_id = cloudstack_pod.foo.id
+  cluster_id = cloudstack_cluster.foo.id
+  zone = cloudstack_zone.foo.name
+}
+
+resource "cloudstack_dedicated_host" "foo" {
+  host_id = cloudstack_host.foo.id
+  domain_id = "%s"
+}`, CLOUDSTACK_DOMAIN_ID)
+}
@@ -0,0 +1,98 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackDedicatedPod() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackDedicatedPodCreate,
+		Read:   resourceCloudStackDedicatedPodRead,
+		Delete: resourceCloudStackDedicatedPodDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: dedicatedSchema("pod_id"),
+	}
+}
+
+func resourceCloudStackDedicatedPodCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	podid := d.Get("pod_id").(string)
+
+	// Create a new parameter struct
+	p := cs.Pod.NewDedicatePodParams(d.Get("domain_id").(string), podid)
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// Dedicate the pod
+	if _, err := cs.Pod.DedicatePod(p); err != nil {
+		return fmt.Errorf("Error dedicating pod %s: %s", podid, err)
+	}
+
+	// The dedication is identified by the pod it applies to
+	d.SetId(podid)
+
+	return resourceCloudStackDedicatedPodRead(d, meta)
+}
+
+func resourceCloudStackDedicatedPodRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Pod.NewListDedicatedPodsParams()
+	p.SetPodid(d.Id())
+
+	// Get the dedication details
+	l, err := cs.Pod.ListDedicatedPods(p)
+	if err != nil {
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] Dedication of pod %s does no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	dedicated := l.DedicatedPods[0]
+
+	if err := d.Set("pod_id", dedicated.Podid); err != nil {
+		return err
+	}
+
+	return setDedicatedOwner(
+		cs, d, dedicated.Domainid, dedicated.Accountid, dedicated.Affinitygroupid)
+}
+
+func resourceCloudStackDedicatedPodDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Pod.NewReleaseDedicatedPodParams(d.Id())
+
+	// Release the pod
+	log.Printf("[INFO] Releasing dedicated pod: %s", d.Id())
+	_, err := cs.Pod.ReleaseDedicatedPod(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error releasing dedicated pod %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackDedicatedPod_basic(t *testing.T) {
+	if CLOUDSTACK_DOMAIN_ID == "" {
+		t.Skip("This test requires the ID of a domain to dedicate the pod to")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackDedicatedPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackDedicatedPod_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackDedicatedPodExists("cloudstack_dedicated_pod.foo"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_dedicated_pod.foo", "domain_id", CLOUDSTACK_DOMAIN_ID),
+				),
+			},
+
+			{
+				ResourceName:      "cloudstack_dedicated_pod.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackDedicatedPodExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated pod ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+		p := cs.Pod.NewListDedicatedPodsParams()
+		p.SetPodid(rs.Primary.ID)
+
+		l, err := cs.Pod.ListDedicatedPods(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count == 0 {
+			return fmt.Errorf("Dedicated pod not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackDedicatedPodDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_dedicated_pod" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No dedicated pod ID is set")
+		}
+
+		p := cs.Pod.NewListDedicatedPodsParams()
+		p.SetPodid(rs.Primary.ID)
+
+		l, err := cs.Pod.ListDedicatedPods(p)
+		if err == nil && l.Count > 0 {
+			return fmt.Errorf("Pod %s is still dedicated", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackDedicatedPod_basic() string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_dedicated_pod" "foo" {
+  pod_id = cloudstack_pod.foo.id
+  domain_id = "%s"
+}`, CLOUDSTACK_DOMAIN_ID)
+}
@@ -0,0 +1,360 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackGlobalLoadBalancerRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackGlobalLoadBalancerRuleCreate,
+		Read:   resourceCloudStackGlobalLoadBalancerRuleRead,
+		Update: resourceCloudStackGlobalLoadBalancerRuleUpdate,
+		Delete: resourceCloudStackGlobalLoadBalancerRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"domain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"service_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"algorithm": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "roundrobin",
+			},
+
+			"persistence": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "sourceip",
+			},
+
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ForceNew: true,
+			},
+
+			"member": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"lb_rule_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudStackGlobalLoadBalancerRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyGlobalLoadBalancerRuleParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewCreateGlobalLoadBalancerRuleParams(
+		d.Get("domain_name").(string),
+		d.Get("service_type").(string),
+		name,
+		d.Get("region_id").(int),
+	)
+
+	// Set the description
+	if description, ok := d.GetOk("description"); ok {
+		p.SetDescription(description.(string))
+	} else {
+		p.SetDescription(name)
+	}
+
+	p.SetGslblbmethod(d.Get("algorithm").(string))
+	p.SetGslbstickysessionmethodname(d.Get("persistence").(string))
+
+	// Create the global load balancer rule
+	r, err := cs.LoadBalancer.CreateGlobalLoadBalancerRule(p)
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer rule %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// Assign the members to the new rule
+	if err := assignGlobalLoadBalancerRuleMembers(d, meta, d.Get("member").(*schema.Set)); err != nil {
+		return err
+	}
+
+	return resourceCloudStackGlobalLoadBalancerRuleRead(d, meta)
+}
+
+func resourceCloudStackGlobalLoadBalancerRuleRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the global load balancer rule details
+	r, count, err := cs.LoadBalancer.GetGlobalLoadBalancerRuleByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf(
+				"[DEBUG] Global load balancer rule %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", r.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", r.Description); err != nil {
+		return err
+	}
+	if err := d.Set("domain_name", r.Gslbdomainname); err != nil {
+		return err
+	}
+	if err := d.Set("service_type", r.Gslbservicetype); err != nil {
+		return err
+	}
+	if err := d.Set("algorithm", r.Gslblbmethod); err != nil {
+		return err
+	}
+	if err := d.Set("persistence", r.Gslbstickysessionmethodname); err != nil {
+		return err
+	}
+	if err := d.Set("region_id", r.Regionid); err != nil {
+		return err
+	}
+
+	// The API does not return the weights, so we keep the configured ones
+	weights := make(map[string]int)
+	for _, m := range d.Get("member").(*schema.Set).List() {
+		m := m.(map[string]interface{})
+		weights[m["lb_rule_id"].(string)] = m["weight"].(int)
+	}
+
+	var members []interface{}
+	for _, lbr := range r.Loadbalancerrule {
+		weight, ok := weights[lbr.Id]
+		if !ok {
+			weight = 1
+		}
+
+		members = append(members, map[string]interface{}{
+			"lb_rule_id": lbr.Id,
+			"weight":     weight,
+		})
+	}
+
+	if err := d.Set("member", members); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackGlobalLoadBalancerRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyGlobalLoadBalancerRuleParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	if d.HasChange("description") || d.HasChange("algorithm") || d.HasChange("persistence") {
+		log.Printf("[DEBUG] Global load balancer rule %s changed, starting update", name)
+
+		// Create a new parameter struct
+		p := cs.LoadBalancer.NewUpdateGlobalLoadBalancerRuleParams(d.Id())
+
+		if d.HasChange("description") {
+			p.SetDescription(d.Get("description").(string))
+		}
+
+		if d.HasChange("algorithm") {
+			p.SetGslblbmethod(d.Get("algorithm").(string))
+		}
+
+		if d.HasChange("persistence") {
+			p.SetGslbstickysessionmethodname(d.Get("persistence").(string))
+		}
+
+		_, err := cs.LoadBalancer.UpdateGlobalLoadBalancerRule(p)
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating global load balancer rule %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("member") {
+		o, n := d.GetChange("member")
+		ombs, nmbs := o.(*schema.Set), n.(*schema.Set)
+
+		// A changed weight shows up as both a removed and an added member,
+		// so we first remove the old members and then assign the new ones
+		if err := removeGlobalLoadBalancerRuleMembers(d, meta, ombs.Difference(nmbs)); err != nil {
+			return err
+		}
+
+		if err := assignGlobalLoadBalancerRuleMembers(d, meta, nmbs.Difference(ombs)); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackGlobalLoadBalancerRuleRead(d, meta)
+}
+
+func resourceCloudStackGlobalLoadBalancerRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewDeleteGlobalLoadBalancerRuleParams(d.Id())
+
+	log.Printf("[INFO] Deleting global load balancer rule: %s", d.Get("name").(string))
+	if _, err := cs.LoadBalancer.DeleteGlobalLoadBalancerRule(p); err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if !strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return fmt.Errorf(
+				"Error deleting global load balancer rule %s: %s", d.Get("name").(string), err)
+		}
+	}
+
+	return nil
+}
+
+func assignGlobalLoadBalancerRuleMembers(d *schema.ResourceData, meta interface{}, members *schema.Set) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if members.Len() == 0 {
+		return nil
+	}
+
+	var ids []string
+	weights := make(map[string]string)
+	for _, m := range members.List() {
+		m := m.(map[string]interface{})
+		id := m["lb_rule_id"].(string)
+
+		ids = append(ids, id)
+		weights[id] = strconv.Itoa(m["weight"].(int))
+	}
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewAssignToGlobalLoadBalancerRuleParams(d.Id(), ids)
+	p.SetGslblbruleweightsmap(weights)
+
+	log.Printf("[DEBUG] Assigning members %v to global load balancer rule %s", ids, d.Id())
+	if _, err := cs.LoadBalancer.AssignToGlobalLoadBalancerRule(p); err != nil {
+		return fmt.Errorf(
+			"Error assigning members to global load balancer rule %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func removeGlobalLoadBalancerRuleMembers(d *schema.ResourceData, meta interface{}, members *schema.Set) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if members.Len() == 0 {
+		return nil
+	}
+
+	var ids []string
+	for _, m := range members.List() {
+		ids = append(ids, m.(map[string]interface{})["lb_rule_id"].(string))
+	}
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewRemoveFromGlobalLoadBalancerRuleParams(d.Id(), ids)
+
+	log.Printf("[DEBUG] Removing members %v from global load balancer rule %s", ids, d.Id())
+	if _, err := cs.LoadBalancer.RemoveFromGlobalLoadBalancerRule(p); err != nil {
+		return fmt.Errorf(
+			"Error removing members from global load balancer rule %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func verifyGlobalLoadBalancerRuleParams(d *schema.ResourceData) error {
+	serviceType := d.Get("service_type").(string)
+	switch serviceType {
+	case "tcp", "udp", "http":
+		// These are supported
+	default:
+		return fmt.Errorf(
+			"%q is not a valid service type. Valid options are 'tcp', 'udp' and 'http'", serviceType)
+	}
+
+	algorithm := d.Get("algorithm").(string)
+	switch algorithm {
+	case "roundrobin", "leastconn", "proximity":
+		// These are supported
+	default:
+		return fmt.Errorf(
+			"%q is not a valid algorithm. Valid options are 'roundrobin', 'leastconn' and 'proximity'",
+			algorithm)
+	}
+
+	persistence := d.Get("persistence").(string)
+	if persistence != "sourceip" {
+		return fmt.Errorf(
+			"%q is not a valid persistence method. The only valid option is 'sourceip'", persistence)
+	}
+
+	members := make(map[string]bool)
+	for _, m := range d.Get("member").(*schema.Set).List() {
+		id := m.(map[string]interface{})["lb_rule_id"].(string)
+		if members[id] {
+			return fmt.Errorf("Load balancer rule %s is configured as a member more than once", id)
+		}
+		members[id] = true
+	}
+
+	return nil
+}
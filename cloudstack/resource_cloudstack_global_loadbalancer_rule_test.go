@@ -0,0 +1,203 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackGlobalLoadBalancerRule_basic(t *testing.T) {
+	var gslb cloudstack.GlobalLoadBalancerRule
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackGlobalLoadBalancerRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackGlobalLoadBalancerRule_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackGlobalLoadBalancerRuleExists(
+						"cloudstack_global_loadbalancer_rule.foo", &gslb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "name", "terraform-gslb"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "domain_name", "terraform-gslb"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "service_type", "tcp"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "algorithm", "roundrobin"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "member.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackGlobalLoadBalancerRule_update(t *testing.T) {
+	var gslb cloudstack.GlobalLoadBalancerRule
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackGlobalLoadBalancerRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackGlobalLoadBalancerRule_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackGlobalLoadBalancerRuleExists(
+						"cloudstack_global_loadbalancer_rule.foo", &gslb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "algorithm", "roundrobin"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "member.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackGlobalLoadBalancerRule_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackGlobalLoadBalancerRuleExists(
+						"cloudstack_global_loadbalancer_rule.foo", &gslb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "description", "terraform gslb"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "algorithm", "leastconn"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_global_loadbalancer_rule.foo", "member.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackGlobalLoadBalancerRuleExists(
+	n string, gslb *cloudstack.GlobalLoadBalancerRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No global load balancer rule ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		r, _, err := cs.LoadBalancer.GetGlobalLoadBalancerRuleByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if r.Id != rs.Primary.ID {
+			return fmt.Errorf("Global load balancer rule not found")
+		}
+
+		*gslb = *r
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackGlobalLoadBalancerRuleDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_global_loadbalancer_rule" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No global load balancer rule ID is set")
+		}
+
+		_, _, err := cs.LoadBalancer.GetGlobalLoadBalancerRuleByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Global load balancer rule %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackGlobalLoadBalancerRule_members = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  source_nat_ip = true
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_ipaddress" "foo" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_ipaddress" "bar" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_loadbalancer_rule" "foo" {
+  name = "terraform-lb1"
+  ip_address_id = "${cloudstack_ipaddress.foo.id}"
+  algorithm = "roundrobin"
+  public_port = 80
+  private_port = 80
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+}
+
+resource "cloudstack_loadbalancer_rule" "bar" {
+  name = "terraform-lb2"
+  ip_address_id = "${cloudstack_ipaddress.bar.id}"
+  algorithm = "roundrobin"
+  public_port = 80
+  private_port = 80
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+}`
+
+const testAccCloudStackGlobalLoadBalancerRule_basic = testAccCloudStackGlobalLoadBalancerRule_members + `
+
+resource "cloudstack_global_loadbalancer_rule" "foo" {
+  name = "terraform-gslb"
+  domain_name = "terraform-gslb"
+  service_type = "tcp"
+
+  member {
+    lb_rule_id = "${cloudstack_loadbalancer_rule.foo.id}"
+  }
+}`
+
+const testAccCloudStackGlobalLoadBalancerRule_update = testAccCloudStackGlobalLoadBalancerRule_members + `
+
+resource "cloudstack_global_loadbalancer_rule" "foo" {
+  name = "terraform-gslb"
+  description = "terraform gslb"
+  domain_name = "terraform-gslb"
+  service_type = "tcp"
+  algorithm = "leastconn"
+
+  member {
+    lb_rule_id = "${cloudstack_loadbalancer_rule.foo.id}"
+    weight = 2
+  }
+
+  member {
+    lb_rule_id = "${cloudstack_loadbalancer_rule.bar.id}"
+    weight = 1
+  }
+}`
@@ -0,0 +1,504 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackHostCreate,
+		Read:   resourceCloudStackHostRead,
+		Update: resourceCloudStackHostUpdate,
+		Delete: resourceCloudStackHostDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"hypervisor": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				ForceNew:  true,
+			},
+
+			"pod_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"cluster_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"host_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"allocation_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Enabled",
+			},
+
+			"maintenance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"maintenance_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  600,
+			},
+
+			"out_of_band_management": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"driver": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"port": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "623",
+						},
+
+						"username": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"password": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackHostCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	url := d.Get("url").(string)
+
+	// Create a new parameter struct
+	p := cs.Host.NewAddHostParams(
+		d.Get("hypervisor").(string),
+		d.Get("pod_id").(string),
+		url,
+		zoneid,
+	)
+
+	if v, ok := d.GetOk("cluster_id"); ok {
+		p.SetClusterid(v.(string))
+	}
+
+	if v, ok := d.GetOk("username"); ok {
+		p.SetUsername(v.(string))
+	}
+
+	if v, ok := d.GetOk("password"); ok {
+		p.SetPassword(v.(string))
+	}
+
+	if v, ok := d.GetOk("host_tags"); ok {
+		var tags []string
+		for _, t := range v.(*schema.Set).List() {
+			tags = append(tags, t.(string))
+		}
+		p.SetHosttags(tags)
+	}
+
+	// Add the new host
+	r, err := cs.Host.AddHost(p)
+	if err != nil {
+		return fmt.Errorf("Error adding host %s: %s", url, err)
+	}
+
+	d.SetId(r.Id)
+
+	// The name, allocation state and maintenance mode can only be set
+	// once the host is added
+	if _, ok := d.GetOk("name"); ok || d.Get("allocation_state").(string) != "Enabled" {
+		if err := updateHost(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := d.GetOk("out_of_band_management"); ok {
+		if err := updateHostOutOfBandManagement(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("maintenance").(bool) {
+		if err := setHostMaintenance(d, meta, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackHostRead(d, meta)
+}
+
+func resourceCloudStackHostRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the host details
+	h, count, err := cs.Host.GetHostByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Host %s does no longer exist", d.Get("url").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", h.Name); err != nil {
+		return err
+	}
+	if err := d.Set("hypervisor", h.Hypervisor); err != nil {
+		return err
+	}
+	if err := d.Set("pod_id", h.Podid); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_id", h.Clusterid); err != nil {
+		return err
+	}
+	if err := d.Set("ip_address", h.Ipaddress); err != nil {
+		return err
+	}
+	if err := d.Set("state", h.State); err != nil {
+		return err
+	}
+	if err := d.Set("resource_state", h.Resourcestate); err != nil {
+		return err
+	}
+
+	var tags []interface{}
+	if h.Hosttags != "" {
+		for _, t := range strings.Split(h.Hosttags, ",") {
+			tags = append(tags, t)
+		}
+	}
+	if err := d.Set("host_tags", schema.NewSet(schema.HashString, tags)); err != nil {
+		return err
+	}
+
+	// The resource state combines the allocation state and the maintenance
+	// mode, so we only update the one that is reflected by the current state
+	switch h.Resourcestate {
+	case "Enabled", "Disabled":
+		if err := d.Set("allocation_state", h.Resourcestate); err != nil {
+			return err
+		}
+		if err := d.Set("maintenance", false); err != nil {
+			return err
+		}
+	case "PrepareForMaintenance", "Maintenance", "ErrorInMaintenance":
+		if err := d.Set("maintenance", true); err != nil {
+			return err
+		}
+	}
+
+	// The password is never returned, so we keep the configured one
+	if _, ok := d.GetOk("out_of_band_management"); ok {
+		oobm := h.Outofbandmanagement
+		if err := d.Set("out_of_band_management", []interface{}{
+			map[string]interface{}{
+				"driver":   oobm.Driver,
+				"address":  oobm.Address,
+				"port":     oobm.Port,
+				"username": oobm.Username,
+				"password": d.Get("out_of_band_management.0.password").(string),
+				"enabled":  oobm.Enabled,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	setValueOrID(d, "zone", h.Zonename, h.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	// Leave maintenance mode first, as it prevents most other changes
+	if d.HasChange("maintenance") && !d.Get("maintenance").(bool) {
+		if err := setHostMaintenance(d, meta, false); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("name") || d.HasChange("host_tags") || d.HasChange("allocation_state") {
+		if err := updateHost(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("out_of_band_management") {
+		if err := updateHostOutOfBandManagement(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("maintenance") && d.Get("maintenance").(bool) {
+		if err := setHostMaintenance(d, meta, true); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackHostRead(d, meta)
+}
+
+func resourceCloudStackHostDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// A host can only be deleted while it is in maintenance
+	if !d.Get("maintenance").(bool) {
+		if err := setHostMaintenance(d, meta, true); err != nil {
+			return err
+		}
+	}
+
+	// Create a new parameter struct
+	p := cs.Host.NewDeleteHostParams(d.Id())
+
+	// Delete the host
+	log.Printf("[INFO] Deleting host: %s", d.Get("url").(string))
+	_, err := cs.Host.DeleteHost(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting host %s: %s", d.Get("url").(string), err)
+	}
+
+	return nil
+}
+
+func updateHost(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Host.NewUpdateHostParams(d.Id())
+
+	if v, ok := d.GetOk("name"); ok {
+		p.SetName(v.(string))
+	}
+
+	if d.HasChange("host_tags") {
+		tags := []string{}
+		for _, t := range d.Get("host_tags").(*schema.Set).List() {
+			tags = append(tags, t.(string))
+		}
+		p.SetHosttags(tags)
+	}
+
+	// The API expects an allocation state event instead of a state
+	if d.HasChange("allocation_state") {
+		if d.Get("allocation_state").(string) == "Enabled" {
+			p.SetAllocationstate("Enable")
+		} else {
+			p.SetAllocationstate("Disable")
+		}
+	}
+
+	log.Printf("[DEBUG] Updating host %s", d.Id())
+	if _, err := cs.Host.UpdateHost(p); err != nil {
+		return fmt.Errorf("Error updating host %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func updateHostOutOfBandManagement(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	oobm, ok := d.GetOk("out_of_band_management")
+	if !ok {
+		log.Printf("[DEBUG] Disabling out-of-band management of host %s", d.Id())
+		if _, err := cs.Host.DisableOutOfBandManagementForHost(
+			cs.Host.NewDisableOutOfBandManagementForHostParams(d.Id())); err != nil {
+			return fmt.Errorf("Error disabling out-of-band management of host %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	m := oobm.([]interface{})[0].(map[string]interface{})
+
+	// Create a new parameter struct
+	p := cs.OutofbandManagement.NewConfigureOutOfBandManagementParams(
+		m["address"].(string),
+		m["driver"].(string),
+		d.Id(),
+		m["password"].(string),
+		m["port"].(string),
+		m["username"].(string),
+	)
+
+	log.Printf("[DEBUG] Configuring out-of-band management of host %s", d.Id())
+	if _, err := cs.OutofbandManagement.ConfigureOutOfBandManagement(p); err != nil {
+		return fmt.Errorf("Error configuring out-of-band management of host %s: %s", d.Id(), err)
+	}
+
+	if m["enabled"].(bool) {
+		_, err := cs.Host.EnableOutOfBandManagementForHost(
+			cs.Host.NewEnableOutOfBandManagementForHostParams(d.Id()))
+		if err != nil {
+			return fmt.Errorf("Error enabling out-of-band management of host %s: %s", d.Id(), err)
+		}
+	} else {
+		_, err := cs.Host.DisableOutOfBandManagementForHost(
+			cs.Host.NewDisableOutOfBandManagementForHostParams(d.Id()))
+		if err != nil {
+			return fmt.Errorf("Error disabling out-of-band management of host %s: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
+
+func setHostMaintenance(d *schema.ResourceData, meta interface{}, maintenance bool) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if !maintenance {
+		log.Printf("[DEBUG] Cancelling maintenance of host %s", d.Id())
+		if _, err := cs.Host.CancelHostMaintenance(
+			cs.Host.NewCancelHostMaintenanceParams(d.Id())); err != nil {
+			return fmt.Errorf("Error cancelling maintenance of host %s: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	log.Printf("[DEBUG] Preparing host %s for maintenance", d.Id())
+	if _, err := cs.Host.PrepareHostForMaintenance(
+		cs.Host.NewPrepareHostForMaintenanceParams(d.Id())); err != nil {
+		return fmt.Errorf("Error preparing host %s for maintenance: %s", d.Id(), err)
+	}
+
+	// Wait until all instances are migrated and the host is in maintenance
+	currentTime := time.Now().Unix()
+	timeout := int64(d.Get("maintenance_timeout").(int))
+	for {
+		h, _, err := cs.Host.GetHostByID(d.Id())
+		if err != nil {
+			return err
+		}
+
+		switch h.Resourcestate {
+		case "Maintenance":
+			return nil
+		case "ErrorInMaintenance":
+			return fmt.Errorf("Error preparing host %s for maintenance", d.Id())
+		}
+
+		if time.Now().Unix()-currentTime > timeout {
+			return fmt.Errorf("Timeout while waiting for host %s to enter maintenance", d.Id())
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+}
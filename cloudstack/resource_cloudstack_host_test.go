@@ -0,0 +1,139 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackHost_basic(t *testing.T) {
+	var host cloudstack.Host
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackHost_basic("Enabled", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackHostExists("cloudstack_host.foo", &host),
+					resource.TestCheckResourceAttr(
+						"cloudstack_host.foo", "resource_state", "Enabled"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_host.foo", "host_tags.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackHost_basic("Disabled", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackHostExists("cloudstack_host.foo", &host),
+					resource.TestCheckResourceAttr(
+						"cloudstack_host.foo", "resource_state", "Disabled"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackHost_basic("Disabled", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackHostExists("cloudstack_host.foo", &host),
+					resource.TestCheckResourceAttr(
+						"cloudstack_host.foo", "resource_state", "Maintenance"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackHostExists(
+	n string, host *cloudstack.Host) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No host ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		h, _, err := cs.Host.GetHostByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if h.Id != rs.Primary.ID {
+			return fmt.Errorf("Host not found")
+		}
+
+		*host = *h
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackHostDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_host" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No host ID is set")
+		}
+
+		_, _, err := cs.Host.GetHostByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Host %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackHost_basic(state string, maintenance bool) string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_cluster" "foo" {
+  name = "terraform-cluster"
+  hypervisor = "Simulator"
+  pod_id = cloudstack_pod.foo.id
+  zone = cloudstack_zone.foo.name
+}
+
+resource "cloudstack_host" "foo" {
+  hypervisor = "Simulator"
+  url = "http://sim/c0/h9"
+  username = "root"
+  password = "password"
+  pod_id = cloudstack_pod.foo.id
+  cluster_id = cloudstack_cluster.foo.id
+  zone = cloudstack_zone.foo.name
+  host_tags = ["terraform"]
+  allocation_state = "%s"
+  maintenance = %t
+}`, state, maintenance)
+}
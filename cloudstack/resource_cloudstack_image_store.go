@@ -0,0 +1,167 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackImageStore() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackImageStoreCreate,
+		Read:   resourceCloudStackImageStoreRead,
+		Delete: resourceCloudStackImageStoreDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"provider_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "NFS",
+				ForceNew: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"details": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				ForceNew:  true,
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"scope": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackImageStoreCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.ImageStore.NewAddImageStoreParams(d.Get("provider_name").(string))
+	p.SetUrl(d.Get("url").(string))
+
+	if v, ok := d.GetOk("name"); ok {
+		p.SetName(v.(string))
+	}
+
+	// Retrieve the zone ID, object stores are available region wide
+	if v, ok := d.GetOk("zone"); ok {
+		zoneid, e := retrieveID(cs, "zone", v.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetZoneid(zoneid)
+	}
+
+	if v, ok := d.GetOk("details"); ok {
+		details := make(map[string]string)
+		for k, v := range v.(map[string]interface{}) {
+			details[k] = v.(string)
+		}
+		p.SetDetails(details)
+	}
+
+	// Add the new image store
+	r, err := cs.ImageStore.AddImageStore(p)
+	if err != nil {
+		return fmt.Errorf("Error adding image store %s: %s", d.Get("url").(string), err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackImageStoreRead(d, meta)
+}
+
+func resourceCloudStackImageStoreRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the image store details
+	s, count, err := cs.ImageStore.GetImageStoreByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Image store %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", s.Name); err != nil {
+		return err
+	}
+	if err := d.Set("url", s.Url); err != nil {
+		return err
+	}
+	if err := d.Set("provider_name", s.Providername); err != nil {
+		return err
+	}
+	if err := d.Set("protocol", s.Protocol); err != nil {
+		return err
+	}
+	if err := d.Set("scope", s.Scope); err != nil {
+		return err
+	}
+
+	if s.Zoneid != "" {
+		setValueOrID(d, "zone", s.Zonename, s.Zoneid)
+	}
+
+	return nil
+}
+
+func resourceCloudStackImageStoreDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.ImageStore.NewDeleteImageStoreParams(d.Id())
+
+	// Delete the image store
+	log.Printf("[INFO] Deleting image store: %s", d.Id())
+	_, err := cs.ImageStore.DeleteImageStore(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting image store %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
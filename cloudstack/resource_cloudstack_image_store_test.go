@@ -0,0 +1,97 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackImageStore_basic(t *testing.T) {
+	var store cloudstack.ImageStore
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackImageStoreDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackImageStore_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackImageStoreExists(
+						"cloudstack_image_store.foo", &store),
+					resource.TestCheckResourceAttr(
+						"cloudstack_image_store.foo", "provider_name", "NFS"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_image_store.foo", "protocol", "nfs"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackImageStoreExists(
+	n string, store *cloudstack.ImageStore) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No image store ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		i, _, err := cs.ImageStore.GetImageStoreByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if i.Id != rs.Primary.ID {
+			return fmt.Errorf("Image store not found")
+		}
+
+		*store = *i
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackImageStoreDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_image_store" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No image store ID is set")
+		}
+
+		_, _, err := cs.ImageStore.GetImageStoreByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Image store %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackImageStore_basic = `
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_image_store" "foo" {
+  name = "terraform-secondary"
+  url = "nfs://10.147.28.6/export/home/sandbox/terraform-secondary"
+  zone = cloudstack_zone.foo.name
+}`
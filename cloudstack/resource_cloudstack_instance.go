@@ -1,11 +1,16 @@
 package cloudstack
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/sha1"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/apache/cloudstack-go/v2/cloudstack"
@@ -21,6 +26,7 @@ func resourceCloudStackInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceCloudStackInstanceImport,
 		},
+		CustomizeDiff: resourceCloudStackInstanceCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -42,7 +48,113 @@ func resourceCloudStackInstance() *schema.Resource {
 				Required: true,
 			},
 
+			"cpu_number": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"cpu_speed": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"memory": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"allow_stop_for_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"network_id": {
+				Type:          schema.TypeString,
+				ConfigMode:    schema.SchemaConfigModeAttr,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"network_interface"},
+			},
+
+			"ip_address": {
+				Type:          schema.TypeString,
+				ConfigMode:    schema.SchemaConfigModeAttr,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"network_interface"},
+			},
+
+			"network_interface": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"ip_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"ip6_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"mac_address": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"default": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"template": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"template", "iso"},
+			},
+
+			"restore_on_template_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"iso": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"template", "iso"},
+			},
+
+			"disk_offering": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"hypervisor": {
 				Type:       schema.TypeString,
 				ConfigMode: schema.SchemaConfigModeAttr,
 				Optional:   true,
@@ -50,7 +162,64 @@ func resourceCloudStackInstance() *schema.Resource {
 				ForceNew:   true,
 			},
 
-			"ip_address": {
+			"data_disk": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"disk_offering": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"min_iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"max_iops": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"datadisk_template_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"root_disk_controller": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"data_disk_controller": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"details": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"boot_type": {
 				Type:       schema.TypeString,
 				ConfigMode: schema.SchemaConfigModeAttr,
 				Optional:   true,
@@ -58,10 +227,12 @@ func resourceCloudStackInstance() *schema.Resource {
 				ForceNew:   true,
 			},
 
-			"template": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+			"boot_mode": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
 			},
 
 			"root_disk_size": {
@@ -69,16 +240,62 @@ func resourceCloudStackInstance() *schema.Resource {
 				ConfigMode: schema.SchemaConfigModeAttr,
 				Optional:   true,
 				Computed:   true,
-				ForceNew:   true,
 			},
 
-			"group": {
+			"resize_root_disk": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"root_disk_storage_pool_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"host_id": {
 				Type:       schema.TypeString,
 				ConfigMode: schema.SchemaConfigModeAttr,
 				Optional:   true,
 				Computed:   true,
 			},
 
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"pod_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"deployment_planner": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"group": {
+				Type:          schema.TypeString,
+				ConfigMode:    schema.SchemaConfigModeAttr,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"group_id"},
+			},
+
+			"group_id": {
+				Type:          schema.TypeString,
+				ConfigMode:    schema.SchemaConfigModeAttr,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"group"},
+			},
+
 			"affinity_group_ids": {
 				Type:          schema.TypeSet,
 				Optional:      true,
@@ -97,8 +314,9 @@ func resourceCloudStackInstance() *schema.Resource {
 
 			"security_group_ids": {
 				Type:          schema.TypeSet,
+				ConfigMode:    schema.SchemaConfigModeAttr,
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 				Elem:          &schema.Schema{Type: schema.TypeString},
 				Set:           schema.HashString,
 				ConflictsWith: []string{"security_group_names"},
@@ -106,13 +324,20 @@ func resourceCloudStackInstance() *schema.Resource {
 
 			"security_group_names": {
 				Type:          schema.TypeSet,
+				ConfigMode:    schema.SchemaConfigModeAttr,
 				Optional:      true,
-				ForceNew:      true,
+				Computed:      true,
 				Elem:          &schema.Schema{Type: schema.TypeString},
 				Set:           schema.HashString,
 				ConflictsWith: []string{"security_group_ids"},
 			},
 
+			"stop_for_security_group_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"project": {
 				Type:       schema.TypeString,
 				ConfigMode: schema.SchemaConfigModeAttr,
@@ -132,6 +357,22 @@ func resourceCloudStackInstance() *schema.Resource {
 				Optional: true,
 			},
 
+			"pgp_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"reset_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"pgp_key"},
+			},
+
+			"encrypted_password": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"start_vm": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -139,6 +380,19 @@ func resourceCloudStackInstance() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"force_stop": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"user_data": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -153,6 +407,25 @@ func resourceCloudStackInstance() *schema.Resource {
 				},
 			},
 
+			"user_data_base64": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"user_data_gzip"},
+			},
+
+			"user_data_gzip": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"stop_for_user_data_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"expunge": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -167,6 +440,10 @@ func resourceCloudStackInstance() *schema.Resource {
 func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	cs := meta.(*cloudstack.CloudStackClient)
 
+	if err := verifyInstanceParams(d); err != nil {
+		return err
+	}
+
 	// Retrieve the service_offering ID
 	serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
 	if e != nil {
@@ -185,15 +462,115 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	// Retrieve the template ID
-	templateid, e := retrieveTemplateID(cs, zone.Id, d.Get("template").(string))
+	// Retrieve the template or ISO ID
+	var templateid string
+	if iso, ok := d.GetOk("iso"); ok {
+		templateid, e = retrieveISOID(cs, zone.Id, iso.(string))
+	} else {
+		templateid, e = retrieveTemplateID(cs, zone.Id, d.Get("template").(string))
+	}
 	if e != nil {
 		return e.Error()
 	}
 
 	// Create a new parameter struct
 	p := cs.VirtualMachine.NewDeployVirtualMachineParams(serviceofferingid, templateid, zone.Id)
-	p.SetStartvm(d.Get("start_vm").(bool))
+
+	// If there is a state supplied, it determines if the instance is started
+	startvm := d.Get("start_vm").(bool)
+	if state, ok := d.GetOk("state"); ok {
+		startvm = state.(string) == "Running"
+	}
+	p.SetStartvm(startvm)
+
+	// If there are custom service offering parameters supplied, add them to
+	// the deploy details
+	details := serviceOfferingDetails(d)
+
+	// If there are disk controllers supplied, add them to the deploy details
+	if controller, ok := d.GetOk("root_disk_controller"); ok {
+		details["rootDiskController"] = controller.(string)
+	}
+	if controller, ok := d.GetOk("data_disk_controller"); ok {
+		details["dataDiskController"] = controller.(string)
+	}
+
+	// Add any other details, the dedicated arguments take precedence
+	for k, v := range d.Get("details").(map[string]interface{}) {
+		if _, ok := details[k]; !ok {
+			details[k] = v.(string)
+		}
+	}
+
+	// If there is a disk_offering supplied, add it to the parameter struct. When
+	// deploying from an ISO this offering is used to create the root disk.
+	if diskoffering, ok := d.GetOk("disk_offering"); ok {
+		diskofferingid, e := retrieveID(cs, "disk_offering", diskoffering.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetDiskofferingid(diskofferingid)
+	}
+
+	// If there are data disks supplied, the disks of multi-disk templates are
+	// mapped to their offerings and any other disks are created and attached
+	// before the instance starts.
+	dataDisks := d.Get("data_disk").([]interface{})
+	datadiskofferings := make(map[string]string)
+	for _, disk := range dataDisks {
+		m := disk.(map[string]interface{})
+
+		diskofferingid, e := retrieveID(cs, "disk_offering", m["disk_offering"].(string))
+		if e != nil {
+			return e.Error()
+		}
+
+		if templatediskid := m["datadisk_template_id"].(string); templatediskid != "" {
+			datadiskofferings[templatediskid] = diskofferingid
+			continue
+		}
+
+		m["disk_offering_id"] = diskofferingid
+	}
+
+	if len(datadiskofferings) > 0 {
+		p.SetDatadiskofferinglist(datadiskofferings)
+	}
+
+	if len(dataDisks) > len(datadiskofferings) {
+		p.SetStartvm(false)
+	}
+
+	if len(details) > 0 {
+		p.SetDetails(details)
+	}
+
+	// If there is a boot type or mode supplied, add it to the parameter struct
+	if boottype, ok := d.GetOk("boot_type"); ok {
+		p.SetBoottype(boottype.(string))
+	}
+	if bootmode, ok := d.GetOk("boot_mode"); ok {
+		p.SetBootmode(bootmode.(string))
+	}
+
+	// If there is a hypervisor supplied, add it to the parameter struct
+	if hypervisor, ok := d.GetOk("hypervisor"); ok {
+		p.SetHypervisor(hypervisor.(string))
+	}
+
+	// If there are placement constraints supplied, add them to the parameter struct
+	if hostid, ok := d.GetOk("host_id"); ok {
+		p.SetHostid(hostid.(string))
+	}
+	if clusterid, ok := d.GetOk("cluster_id"); ok {
+		p.SetClusterid(clusterid.(string))
+	}
+	if podid, ok := d.GetOk("pod_id"); ok {
+		p.SetPodid(podid.(string))
+	}
+	if planner, ok := d.GetOk("deployment_planner"); ok {
+		p.SetDeploymentplanner(planner.(string))
+	}
 
 	// Set the name
 	name, hasName := d.GetOk("name")
@@ -213,7 +590,28 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 		p.SetRootdisksize(int64(rootdisksize.(int)))
 	}
 
-	if zone.Networktype == "Advanced" {
+	// If there are network interfaces supplied, deploy the instance with a NIC
+	// for each of them in the configured order
+	if nics := d.Get("network_interface").([]interface{}); len(nics) > 0 {
+		var networks []map[string]string
+		for _, nic := range nics {
+			n := nic.(map[string]interface{})
+
+			m := map[string]string{"networkid": n["network_id"].(string)}
+			if ip := n["ip_address"].(string); ip != "" {
+				m["ip"] = ip
+			}
+			if ip6 := n["ip6_address"].(string); ip6 != "" {
+				m["ipv6"] = ip6
+			}
+			if mac := n["mac_address"].(string); mac != "" {
+				m["mac"] = mac
+			}
+
+			networks = append(networks, m)
+		}
+		p.SetIptonetworklist(networks)
+	} else if zone.Networktype == "Advanced" {
 		// Set the default network ID
 		p.SetNetworkids([]string{d.Get("network_id").(string)})
 	}
@@ -228,6 +626,15 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 		p.SetGroup(group.(string))
 	}
 
+	// If there is a group ID supplied, add the name of that group instead
+	if groupid, ok := d.GetOk("group_id"); ok {
+		group, err := retrieveInstanceGroupName(cs, d, groupid.(string))
+		if err != nil {
+			return err
+		}
+		p.SetGroup(group)
+	}
+
 	// If there are affinity group IDs supplied, add them to the parameter struct
 	if agIDs := d.Get("affinity_group_ids").(*schema.Set); agIDs.Len() > 0 {
 		var groups []string
@@ -275,7 +682,12 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 	}
 
 	if userData, ok := d.GetOk("user_data"); ok {
-		ud, err := getUserData(userData.(string), cs.HTTPGETOnly)
+		ud, err := getUserData(
+			userData.(string),
+			d.Get("user_data_base64").(bool),
+			d.Get("user_data_gzip").(bool),
+			cs.HTTPGETOnly,
+		)
 		if err != nil {
 			return err
 		}
@@ -290,6 +702,38 @@ func resourceCloudStackInstanceCreate(d *schema.ResourceData, meta interface{})
 
 	d.SetId(r.Id)
 
+	// Store the generated password encrypted, so it never ends up in the state
+	if pgpKey, ok := d.GetOk("pgp_key"); ok && r.Password != "" {
+		encrypted, err := encryptPassword(pgpKey.(string), r.Password)
+		if err != nil {
+			return err
+		}
+
+		if err := d.Set("encrypted_password", encrypted); err != nil {
+			return err
+		}
+	}
+
+	// The first NIC is the default one, so update it if needed
+	if err := setDefaultNetworkInterface(cs, d, r.Nic); err != nil {
+		return err
+	}
+
+	// Attach the remaining data disks and start the instance afterwards
+	if len(dataDisks) > 0 {
+		if err := createDataDisks(cs, d, r.Name, zone.Id, dataDisks); err != nil {
+			return err
+		}
+	}
+
+	if len(dataDisks) > len(datadiskofferings) {
+		if startvm {
+			if err := startInstance(cs, d); err != nil {
+				return fmt.Errorf("Error starting instance %s: %s", name, err)
+			}
+		}
+	}
+
 	// Set tags if necessary
 	if err = setTags(cs, d, "userVm"); err != nil {
 		return fmt.Errorf("Error setting tags on the new instance %s: %s", name, err)
@@ -329,9 +773,15 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 	if err := d.Set("display_name", vm.Displayname); err != nil {
 		return err
 	}
+	if err := d.Set("state", vm.State); err != nil {
+		return err
+	}
 	if err := d.Set("group", vm.Group); err != nil {
 		return err
 	}
+	if err := d.Set("group_id", vm.Groupid); err != nil {
+		return err
+	}
 
 	// In some rare cases (when destroying a machine failes) it can happen that
 	// an instance does not have any attached NIC anymore.
@@ -344,6 +794,23 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
+	if _, ok := d.GetOk("network_interface"); ok {
+		var nics []interface{}
+		for _, n := range sortNics(vm.Nic) {
+			nics = append(nics, map[string]interface{}{
+				"network_id":  n.Networkid,
+				"ip_address":  n.Ipaddress,
+				"ip6_address": n.Ip6address,
+				"mac_address": n.Macaddress,
+				"default":     n.Isdefault,
+				"id":          n.Id,
+			})
+		}
+		if err := d.Set("network_interface", nics); err != nil {
+			return err
+		}
+	}
+
 	// Create a new param struct.
 	p := cs.Volume.NewListVolumesParams()
 	p.SetType("ROOT")
@@ -355,13 +822,27 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
-	// If we found the root disk, then update its size.
+	// If we found the root disk, then update its size and storage pool.
 	if len(l.Volumes) != 1 {
 		log.Printf("[DEBUG] Failed to find root disk of instance: %s", vm.Name)
 	} else {
 		if err := d.Set("root_disk_size", l.Volumes[0].Size>>30); err != nil { // B to GiB
 			return err
 		}
+		if err := d.Set("root_disk_storage_pool_id", l.Volumes[0].Storageid); err != nil {
+			return err
+		}
+	}
+
+	// A stopped instance is not on any host, so keep the last known host
+	if vm.Hostid != "" {
+		if err := d.Set("host_id", vm.Hostid); err != nil {
+			return err
+		}
+	}
+
+	if err := readDataDisks(cs, d); err != nil {
+		return err
 	}
 
 	if _, ok := d.GetOk("affinity_group_ids"); ok {
@@ -384,24 +865,19 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
-	if _, ok := d.GetOk("security_group_ids"); ok {
-		groups := &schema.Set{F: schema.HashString}
-		for _, group := range vm.Securitygroup {
-			groups.Add(group.Id)
-		}
-		if err := d.Set("security_group_ids", groups); err != nil {
-			return err
-		}
+	// Always read back all security groups, so groups that were added outside
+	// of Terraform show up as a diff
+	sgIDs := &schema.Set{F: schema.HashString}
+	sgNames := &schema.Set{F: schema.HashString}
+	for _, group := range vm.Securitygroup {
+		sgIDs.Add(group.Id)
+		sgNames.Add(group.Name)
 	}
-
-	if _, ok := d.GetOk("security_group_names"); ok {
-		groups := &schema.Set{F: schema.HashString}
-		for _, group := range vm.Securitygroup {
-			groups.Add(group.Name)
-		}
-		if err := d.Set("security_group_names", groups); err != nil {
-			return err
-		}
+	if err := d.Set("security_group_ids", sgIDs); err != nil {
+		return err
+	}
+	if err := d.Set("security_group_names", sgNames); err != nil {
+		return err
 	}
 
 	tags := make(map[string]interface{})
@@ -413,17 +889,84 @@ func resourceCloudStackInstanceRead(d *schema.ResourceData, meta interface{}) er
 	}
 
 	setValueOrID(d, "service_offering", vm.Serviceofferingname, vm.Serviceofferingid)
-	setValueOrID(d, "template", vm.Templatename, vm.Templateid)
-	setValueOrID(d, "project", vm.Project, vm.Projectid)
-	setValueOrID(d, "zone", vm.Zonename, vm.Zoneid)
-
-	return nil
-}
 
-func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
-	cs := meta.(*cloudstack.CloudStackClient)
+	if _, ok := d.GetOk("cpu_number"); ok {
+		if err := d.Set("cpu_number", vm.Cpunumber); err != nil {
+			return err
+		}
+	}
 
-	name := d.Get("name").(string)
+	if _, ok := d.GetOk("cpu_speed"); ok {
+		if err := d.Set("cpu_speed", vm.Cpuspeed); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := d.GetOk("memory"); ok {
+		if err := d.Set("memory", vm.Memory); err != nil {
+			return err
+		}
+	}
+
+	if err := d.Set("hypervisor", vm.Hypervisor); err != nil {
+		return err
+	}
+	if err := d.Set("boot_type", vm.Boottype); err != nil {
+		return err
+	}
+	if err := d.Set("boot_mode", vm.Bootmode); err != nil {
+		return err
+	}
+
+	if _, ok := d.GetOk("root_disk_controller"); ok {
+		if err := d.Set("root_disk_controller", vm.Details["rootDiskController"]); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := d.GetOk("data_disk_controller"); ok {
+		if err := d.Set("data_disk_controller", vm.Details["dataDiskController"]); err != nil {
+			return err
+		}
+	}
+
+	// Only return details that were explicitly set
+	if configured, ok := d.GetOk("details"); ok {
+		details := make(map[string]interface{})
+		for k := range configured.(map[string]interface{}) {
+			if v, ok := vm.Details[k]; ok {
+				details[k] = v
+			}
+		}
+		if err := d.Set("details", details); err != nil {
+			return err
+		}
+	}
+
+	// An instance deployed from an ISO reports the ISO as its template
+	if _, ok := d.GetOk("iso"); ok {
+		setValueOrID(d, "iso", vm.Templatename, vm.Templateid)
+	} else {
+		setValueOrID(d, "template", vm.Templatename, vm.Templateid)
+	}
+
+	if _, ok := d.GetOk("disk_offering"); ok {
+		setValueOrID(d, "disk_offering", vm.Diskofferingname, vm.Diskofferingid)
+	}
+	setValueOrID(d, "project", vm.Project, vm.Projectid)
+	setValueOrID(d, "zone", vm.Zonename, vm.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyInstanceParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
 
 	// Check if the display name is changed and if so, update the virtual machine
 	if d.HasChange("display_name") {
@@ -444,14 +987,28 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	// Check if the group is changed and if so, update the virtual machine
-	if d.HasChange("group") {
+	if d.HasChange("group") || d.HasChange("group_id") {
 		log.Printf("[DEBUG] Group changed for %s, starting update", name)
 
 		// Create a new parameter struct
 		p := cs.VirtualMachine.NewUpdateVirtualMachineParams(d.Id())
 
+		group := d.Get("group").(string)
+
+		// Groups can only be assigned by name, so lookup the name of the group
+		if d.HasChange("group_id") {
+			group = ""
+			if groupid := d.Get("group_id").(string); groupid != "" {
+				var err error
+				group, err = retrieveInstanceGroupName(cs, d, groupid)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		// Set the new group
-		p.SetGroup(d.Get("group").(string))
+		p.SetGroup(group)
 
 		// Update the display name
 		_, err := cs.VirtualMachine.UpdateVirtualMachine(p)
@@ -461,15 +1018,87 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	// Attributes that require reboot to update
-	if d.HasChange("name") || d.HasChange("service_offering") || d.HasChange("affinity_group_ids") ||
-		d.HasChange("affinity_group_names") || d.HasChange("keypair") || d.HasChange("user_data") {
-		// Before we can actually make these changes, the virtual machine must be stopped
-		_, err := cs.VirtualMachine.StopVirtualMachine(
-			cs.VirtualMachine.NewStopVirtualMachineParams(d.Id()))
+	// Check if the template has changed and if so, restore the instance from
+	// the new template
+	if d.HasChange("template") {
+		log.Printf("[DEBUG] Template changed for %s, starting restore", name)
+
+		// Retrieve the zone ID
+		zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+		if e != nil {
+			return e.Error()
+		}
+
+		// Retrieve the template ID
+		templateid, e := retrieveTemplateID(cs, zoneid, d.Get("template").(string))
+		if e != nil {
+			return e.Error()
+		}
+
+		// Create a new parameter struct
+		p := cs.VirtualMachine.NewRestoreVirtualMachineParams(d.Id())
+		p.SetTemplateid(templateid)
+
+		// Restore the instance
+		_, err := cs.VirtualMachine.RestoreVirtualMachine(p)
 		if err != nil {
 			return fmt.Errorf(
-				"Error stopping instance %s before making changes: %s", name, err)
+				"Error restoring instance %s from template %s: %s", name, templateid, err)
+		}
+	}
+
+	// Check if the root disk size has changed and if so, resize the root disk
+	if d.HasChange("root_disk_size") {
+		log.Printf("[DEBUG] Root disk size changed for %s, starting resize", name)
+
+		if err := resizeRootDisk(cs, d); err != nil {
+			return err
+		}
+	}
+
+	// Check if the service offering or its custom parameters have changed and
+	// if so, try to scale the instance without stopping it
+	scaleOffline := false
+	if d.HasChange("service_offering") || d.HasChange("cpu_number") ||
+		d.HasChange("cpu_speed") || d.HasChange("memory") {
+		log.Printf("[DEBUG] Service offering changed for %s, starting update", name)
+
+		scaled, err := scaleInstance(cs, d)
+		if err != nil {
+			return err
+		}
+		scaleOffline = !scaled
+	}
+
+	// Keep track of the power state, so a stopped instance stays stopped
+	o, _ := d.GetChange("state")
+	state := o.(string)
+
+	// The user data can be updated without a reboot if allowed, in which case
+	// the instance only picks it up on its next boot
+	userDataChanged := d.HasChange("user_data") || d.HasChange("user_data_base64") ||
+		d.HasChange("user_data_gzip")
+	userDataReboot := userDataChanged && d.Get("stop_for_user_data_update").(bool)
+
+	// Attributes that require reboot to update
+	if d.HasChange("name") || scaleOffline || d.HasChange("affinity_group_ids") ||
+		d.HasChange("affinity_group_names") || d.HasChange("keypair") || userDataReboot ||
+		d.HasChange("reset_password") || d.HasChange("details") {
+		var err error
+
+		// Before we can actually make these changes, the virtual machine must be stopped
+		if state != "Stopped" {
+			if !d.Get("allow_stop_for_update").(bool) {
+				return fmt.Errorf(
+					"Instance %s needs to be stopped to apply the changes, "+
+						"but allow_stop_for_update is false", name)
+			}
+
+			if err := stopInstance(cs, d); err != nil {
+				return fmt.Errorf(
+					"Error stopping instance %s before making changes: %s", name, err)
+			}
+			state = "Stopped"
 		}
 
 		// Check if the name has changed and if so, update the name
@@ -490,24 +1119,11 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 			}
 		}
 
-		// Check if the service offering is changed and if so, update the offering
-		if d.HasChange("service_offering") {
-			log.Printf("[DEBUG] Service offering changed for %s, starting update", name)
-
-			// Retrieve the service_offering ID
-			serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
-			if e != nil {
-				return e.Error()
-			}
-
-			// Create a new parameter struct
-			p := cs.VirtualMachine.NewChangeServiceForVirtualMachineParams(d.Id(), serviceofferingid)
-
-			// Change the service offering
-			_, err = cs.VirtualMachine.ChangeServiceForVirtualMachine(p)
-			if err != nil {
-				return fmt.Errorf(
-					"Error changing the service offering for instance %s: %s", name, err)
+		// Check if the instance could not be scaled live and if so, change the
+		// service offering now it is stopped
+		if scaleOffline {
+			if err := changeServiceForInstance(cs, d); err != nil {
+				return err
 			}
 		}
 
@@ -570,29 +1186,156 @@ func resourceCloudStackInstanceUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 		// Check if the user data has changed and if so, update the user data
-		if d.HasChange("user_data") {
-			log.Printf("[DEBUG] user_data changed for %s, starting update", name)
+		if userDataReboot {
+			if err := updateUserData(cs, d); err != nil {
+				return err
+			}
+		}
 
-			ud, err := getUserData(d.Get("user_data").(string), cs.HTTPGETOnly)
-			if err != nil {
+		// Check if the details have changed and if so, update the details
+		if d.HasChange("details") {
+			if err := updateInstanceDetails(cs, d); err != nil {
 				return err
 			}
+		}
 
-			p := cs.VirtualMachine.NewUpdateVirtualMachineParams(d.Id())
-			p.SetUserdata(ud)
-			_, err = cs.VirtualMachine.UpdateVirtualMachine(p)
+		// Check if a password reset is requested and if so, reset the password
+		if d.HasChange("reset_password") {
+			log.Printf("[DEBUG] Password reset requested for %s, starting reset", name)
+
+			p := cs.VirtualMachine.NewResetPasswordForVirtualMachineParams(d.Id())
+			r, err := cs.VirtualMachine.ResetPasswordForVirtualMachine(p)
+			if err != nil {
+				return fmt.Errorf(
+					"Error resetting the password for instance %s: %s", name, err)
+			}
+
+			encrypted, err := encryptPassword(d.Get("pgp_key").(string), r.Password)
 			if err != nil {
+				return err
+			}
+
+			if err := d.Set("encrypted_password", encrypted); err != nil {
+				return err
+			}
+		}
+
+		// Start the virtual machine again, unless it should be stopped
+		if d.Get("state").(string) != "Stopped" {
+			if err := startInstance(cs, d); err != nil {
+				return fmt.Errorf(
+					"Error starting instance %s after making changes: %s", name, err)
+			}
+			state = "Running"
+		}
+	}
+
+	// Check if the user data has changed and if so, update it in place
+	if userDataChanged && !userDataReboot {
+		if err := updateUserData(cs, d); err != nil {
+			return err
+		}
+	}
+
+	// Check if the security groups have changed and if so, update the groups
+	if d.HasChange("security_group_ids") || d.HasChange("security_group_names") {
+		log.Printf("[DEBUG] Security groups changed for %s, starting update", name)
+
+		// Create a new parameter struct
+		p := cs.VirtualMachine.NewUpdateVirtualMachineParams(d.Id())
+
+		var groups []string
+		if d.HasChange("security_group_ids") {
+			for _, group := range d.Get("security_group_ids").(*schema.Set).List() {
+				groups = append(groups, group.(string))
+			}
+			p.SetSecuritygroupids(groups)
+		} else {
+			for _, group := range d.Get("security_group_names").(*schema.Set).List() {
+				groups = append(groups, group.(string))
+			}
+			p.SetSecuritygroupnames(groups)
+		}
+
+		// Update the security groups
+		_, err := cs.VirtualMachine.UpdateVirtualMachine(p)
+
+		// Some hypervisors only allow changing the security groups of a stopped
+		// instance, so stop it first if that is allowed
+		if err != nil && state != "Stopped" && requiresStoppedInstance(err) &&
+			d.Get("stop_for_security_group_update").(bool) {
+			log.Printf("[DEBUG] Failed to update the security groups of running instance %s: %s", name, err)
+
+			if !d.Get("allow_stop_for_update").(bool) {
 				return fmt.Errorf(
-					"Error updating user_data for instance %s: %s", name, err)
+					"Instance %s needs to be stopped to apply the changes, "+
+						"but allow_stop_for_update is false", name)
+			}
+
+			if err := stopInstance(cs, d); err != nil {
+				return fmt.Errorf(
+					"Error stopping instance %s before making changes: %s", name, err)
+			}
+			state = "Stopped"
+
+			_, err = cs.VirtualMachine.UpdateVirtualMachine(p)
+
+			// Don't leave the instance stopped when the update failed anyway
+			if err != nil && d.Get("state").(string) != "Stopped" {
+				if e := startInstance(cs, d); e != nil {
+					return fmt.Errorf(
+						"Error updating the security groups for instance %s: %s "+
+							"(starting the instance again also failed: %s)", name, err, e)
+				}
 			}
 		}
 
-		// Start the virtual machine again
-		_, err = cs.VirtualMachine.StartVirtualMachine(
-			cs.VirtualMachine.NewStartVirtualMachineParams(d.Id()))
 		if err != nil {
 			return fmt.Errorf(
-				"Error starting instance %s after making changes", name)
+				"Error updating the security groups for instance %s: %s", name, err)
+		}
+	}
+
+	// Check if the host or root disk storage pool has changed and if so, migrate
+	// the instance. A stopped instance is started on the new host.
+	if d.HasChange("host_id") || d.HasChange("root_disk_storage_pool_id") {
+		if err := migrateInstance(cs, d, state); err != nil {
+			return fmt.Errorf("Error migrating instance %s: %s", name, err)
+		}
+	}
+
+	// Check if the state has changed and if so, start or stop the instance. This
+	// also starts an instance that was stopped to update its security groups.
+	switch d.Get("state").(string) {
+	case "Running":
+		if state != "Running" {
+			log.Printf("[DEBUG] Starting instance %s", name)
+			if err := startInstance(cs, d); err != nil {
+				return fmt.Errorf("Error starting instance %s: %s", name, err)
+			}
+		}
+	case "Stopped":
+		if state != "Stopped" {
+			log.Printf("[DEBUG] Stopping instance %s", name)
+			if err := stopInstance(cs, d); err != nil {
+				return fmt.Errorf("Error stopping instance %s: %s", name, err)
+			}
+		}
+	}
+
+	// Check if the data disks have changed and if so, attach, create, resize
+	// or destroy them
+	if d.HasChange("data_disk") {
+		if err := updateDataDisks(cs, d); err != nil {
+			return fmt.Errorf("Error updating data disks of instance %s: %s", name, err)
+		}
+	}
+
+	// Check if the network interfaces have changed and if so, add or remove
+	// the non-default NICs
+	if d.HasChange("network_interface") {
+		if err := updateNetworkInterfaces(cs, d); err != nil {
+			return err
 		}
 	}
 
@@ -616,6 +1359,26 @@ func resourceCloudStackInstanceDelete(d *schema.ResourceData, meta interface{})
 		p.SetExpunge(true)
 	}
 
+	// Data disks created with the instance are destroyed with it, as long as
+	// they are still attached to it
+	if disks := dataDiskIDs(d); len(disks) > 0 {
+		attached, err := listDataDisks(cs, d)
+		if err != nil {
+			return err
+		}
+
+		var volumes []string
+		for _, v := range attached {
+			if disks[v.Id] {
+				volumes = append(volumes, v.Id)
+			}
+		}
+
+		if len(volumes) > 0 {
+			p.SetVolumeids(volumes)
+		}
+	}
+
 	log.Printf("[INFO] Destroying instance: %s", d.Get("name").(string))
 	if _, err := cs.VirtualMachine.DestroyVirtualMachine(p); err != nil {
 		// This is a very poor way to be told the ID does no longer exist :(
@@ -636,14 +1399,976 @@ func resourceCloudStackInstanceImport(d *schema.ResourceData, meta interface{})
 	if err := d.Set("start_vm", true); err != nil {
 		return []*schema.ResourceData{d}, err
 	}
-	return importStatePassthrough(d, meta)
+
+	// The flags that only control how changes are applied are not returned by
+	// the API, so set them to their defaults as well
+	for k, v := range map[string]bool{
+		"allow_stop_for_update":          true,
+		"force_stop":                     false,
+		"resize_root_disk":               false,
+		"restore_on_template_change":     false,
+		"stop_for_security_group_update": false,
+		"stop_for_user_data_update":      true,
+		"user_data_base64":               false,
+		"user_data_gzip":                 false,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return []*schema.ResourceData{d}, err
+		}
+	}
+
+	if _, err := importStatePassthrough(d, meta); err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+
+	// Record the attached data disks, so they can be matched against the
+	// configured data disks
+	if err := importDataDisks(meta.(*cloudstack.CloudStackClient), d); err != nil {
+		return []*schema.ResourceData{d}, err
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
-// getUserData returns the user data as a base64 encoded string
-func getUserData(userData string, httpGetOnly bool) (string, error) {
-	ud := userData
-	if _, err := base64.StdEncoding.DecodeString(ud); err != nil {
-		ud = base64.StdEncoding.EncodeToString([]byte(userData))
+func resourceCloudStackInstanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Validate the user data when planning instead of when applying. The
+	// user_data attribute only holds a hash, so use the configured value.
+	if ud := d.GetRawConfig().GetAttr("user_data"); ud.IsKnown() && !ud.IsNull() {
+		cs := meta.(*cloudstack.CloudStackClient)
+		if _, err := getUserData(
+			ud.AsString(),
+			d.Get("user_data_base64").(bool),
+			d.Get("user_data_gzip").(bool),
+			cs.HTTPGETOnly,
+		); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	// A template change recreates the instance unless it can be restored
+	if d.HasChange("template") && !d.Get("restore_on_template_change").(bool) {
+		if err := d.ForceNew("template"); err != nil {
+			return err
+		}
+	}
+
+	// The root disk can only grow in place
+	if d.HasChange("root_disk_size") {
+		o, n := d.GetChange("root_disk_size")
+		if !d.Get("resize_root_disk").(bool) || n.(int) < o.(int) {
+			if err := d.ForceNew("root_disk_size"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// A stopped instance can only be moved to another host by starting it there
+	if d.HasChange("host_id") && d.NewValueKnown("host_id") {
+		o, n := d.GetChange("state")
+		if hostid := d.Get("host_id").(string); hostid != "" && o.(string) == "Stopped" && n.(string) == "Stopped" {
+			return fmt.Errorf(
+				"Instance %s is stopped and can only be moved to host %s by starting it, "+
+					"set state to \"Running\" to start it on the new host", d.Id(), hostid)
+		}
+	}
+
+	// Make sure a running instance can actually be migrated to the new host
+	if d.HasChange("host_id") && d.NewValueKnown("host_id") {
+		o, n := d.GetChange("state")
+		if hostid := d.Get("host_id").(string); hostid != "" && o.(string) == "Running" && n.(string) != "Stopped" {
+			cs := meta.(*cloudstack.CloudStackClient)
+			if _, err := findMigrationHost(cs, d.Id(), hostid); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Only non-default NICs can be added or removed in place, and new NICs
+	// are always attached after the existing ones
+	if d.HasChange("network_interface") {
+		o, n := d.GetChange("network_interface")
+		if !networkInterfacesUpdatable(o.([]interface{}), n.([]interface{})) {
+			if err := d.ForceNew("network_interface"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// networkInterfacesUpdatable returns true if the old NICs can be turned into
+// the new NICs by removing and appending non-default NICs
+func networkInterfacesUpdatable(o, n []interface{}) bool {
+	networks := make(map[string]bool)
+	for _, nic := range n {
+		networks[nic.(map[string]interface{})["network_id"].(string)] = true
+	}
+
+	var kept []map[string]interface{}
+	for _, nic := range o {
+		m := nic.(map[string]interface{})
+		if networks[m["network_id"].(string)] {
+			kept = append(kept, m)
+		} else if m["default"].(bool) {
+			return false
+		}
+	}
+
+	if len(kept) > len(n) {
+		return false
+	}
+
+	for i, nic := range n {
+		m := nic.(map[string]interface{})
+
+		if i >= len(kept) {
+			if m["default"].(bool) || m["ip6_address"].(string) != "" {
+				return false
+			}
+			continue
+		}
+
+		for _, k := range []string{"network_id", "ip_address", "ip6_address", "mac_address"} {
+			if v := m[k].(string); v != "" && v != kept[i][k].(string) {
+				return false
+			}
+		}
+
+		if m["default"].(bool) != kept[i]["default"].(bool) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// updateNetworkInterfaces removes the NICs that are no longer configured and
+// attaches the newly configured ones
+func updateNetworkInterfaces(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	o, n := d.GetChange("network_interface")
+
+	networks := make(map[string]bool)
+	for _, nic := range o.([]interface{}) {
+		networks[nic.(map[string]interface{})["network_id"].(string)] = true
+	}
+
+	keep := make(map[string]bool)
+	for _, nic := range n.([]interface{}) {
+		m := nic.(map[string]interface{})
+		keep[m["network_id"].(string)] = true
+
+		if networks[m["network_id"].(string)] {
+			continue
+		}
+
+		p := cs.VirtualMachine.NewAddNicToVirtualMachineParams(m["network_id"].(string), d.Id())
+
+		if ip := m["ip_address"].(string); ip != "" {
+			p.SetIpaddress(ip)
+		}
+
+		if mac := m["mac_address"].(string); mac != "" {
+			p.SetMacaddress(mac)
+		}
+
+		log.Printf("[DEBUG] Adding NIC for network %s to instance %s", m["network_id"].(string), d.Id())
+		if _, err := Retry(10, retryableAddNicFunc(cs, p)); err != nil {
+			return fmt.Errorf("Error adding NIC for network %s: %s", m["network_id"].(string), err)
+		}
+	}
+
+	for _, nic := range o.([]interface{}) {
+		m := nic.(map[string]interface{})
+		if keep[m["network_id"].(string)] {
+			continue
+		}
+
+		p := cs.VirtualMachine.NewRemoveNicFromVirtualMachineParams(m["id"].(string), d.Id())
+
+		log.Printf("[DEBUG] Removing NIC %s from instance %s", m["id"].(string), d.Id())
+		if _, err := cs.VirtualMachine.RemoveNicFromVirtualMachine(p); err != nil {
+			return fmt.Errorf("Error removing NIC %s: %s", m["id"].(string), err)
+		}
+	}
+
+	return nil
+}
+
+// setDefaultNetworkInterface makes the NIC of the network interface marked as
+// default the default NIC of the instance
+func setDefaultNetworkInterface(cs *cloudstack.CloudStackClient, d *schema.ResourceData, nics []cloudstack.Nic) error {
+	for _, nic := range d.Get("network_interface").([]interface{}) {
+		m := nic.(map[string]interface{})
+		if !m["default"].(bool) {
+			continue
+		}
+
+		for _, n := range nics {
+			if n.Networkid != m["network_id"].(string) || n.Isdefault {
+				continue
+			}
+
+			p := cs.VirtualMachine.NewUpdateDefaultNicForVirtualMachineParams(n.Id, d.Id())
+			if _, err := cs.VirtualMachine.UpdateDefaultNicForVirtualMachine(p); err != nil {
+				return fmt.Errorf("Error setting NIC %s as default NIC: %s", n.Id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortNics returns the NICs ordered by their device ID
+func sortNics(nics []cloudstack.Nic) []cloudstack.Nic {
+	sorted := make([]cloudstack.Nic, len(nics))
+	copy(sorted, nics)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := strconv.Atoi(sorted[i].Deviceid)
+		b, _ := strconv.Atoi(sorted[j].Deviceid)
+		return a < b
+	})
+
+	return sorted
+}
+
+// createDataDisks creates the given data disks and attaches them to the instance
+func createDataDisks(cs *cloudstack.CloudStackClient, d *schema.ResourceData, name, zoneid string, disks []interface{}) error {
+	// The disks of multi-disk templates are created by the deploy call, so
+	// look them up to record their IDs
+	volumes, err := listDataDisks(cs, d)
+	if err != nil {
+		return err
+	}
+
+	for i, disk := range disks {
+		m := disk.(map[string]interface{})
+
+		if templatediskid := m["datadisk_template_id"].(string); templatediskid != "" {
+			for _, v := range volumes {
+				if v.Templateid == templatediskid {
+					m["id"] = v.Id
+				}
+			}
+			continue
+		}
+
+		diskofferingid := m["disk_offering_id"].(string)
+		delete(m, "disk_offering_id")
+
+		id, err := createDataDisk(cs, d, name, zoneid, diskofferingid, i, m)
+		if err != nil {
+			return err
+		}
+		m["id"] = id
+	}
+
+	return d.Set("data_disk", disks)
+}
+
+// createDataDisk creates a single data disk, attaches it to the instance and
+// returns its ID
+func createDataDisk(cs *cloudstack.CloudStackClient, d *schema.ResourceData, name, zoneid, diskofferingid string, i int, m map[string]interface{}) (string, error) {
+	// Create a new parameter struct
+	p := cs.Volume.NewCreateVolumeParams()
+	p.SetName(fmt.Sprintf("%s-data-%d", name, i+1))
+	p.SetDiskofferingid(diskofferingid)
+	p.SetZoneid(zoneid)
+	p.SetVirtualmachineid(d.Id())
+
+	if size := m["size"].(int); size > 0 {
+		p.SetSize(int64(size))
+	}
+
+	if iops := m["min_iops"].(int); iops > 0 {
+		p.SetMiniops(int64(iops))
+	}
+
+	if iops := m["max_iops"].(int); iops > 0 {
+		p.SetMaxiops(int64(iops))
+	}
+
+	if err := setProjectid(p, cs, d); err != nil {
+		return "", err
+	}
+
+	// Create and attach the data disk
+	r, err := cs.Volume.CreateVolume(p)
+	if err != nil {
+		return "", fmt.Errorf("Error creating data disk %d of instance %s: %s", i+1, d.Id(), err)
+	}
+
+	return r.Id, nil
+}
+
+// updateDataDisks brings the data disks of the instance in line with the
+// configuration. Disks are matched by their position in the list: disks that
+// were detached are attached again, missing and added disks are created,
+// changed disks are resized and removed disks are destroyed.
+func updateDataDisks(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	attached, err := listDataDisks(cs, d)
+	if err != nil {
+		return err
+	}
+
+	volumes := make(map[string]*cloudstack.Volume, len(attached))
+	for _, v := range attached {
+		volumes[v.Id] = v
+	}
+
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	o, n := d.GetChange("data_disk")
+	disks := n.([]interface{})
+
+	keep := make(map[string]bool)
+	for i, disk := range disks {
+		m := disk.(map[string]interface{})
+		id := m["id"].(string)
+
+		diskofferingid, e := retrieveID(cs, "disk_offering", m["disk_offering"].(string))
+		if e != nil {
+			return e.Error()
+		}
+
+		if v, ok := volumes[id]; ok {
+			if err := resizeDataDisk(cs, v, diskofferingid, m); err != nil {
+				return err
+			}
+			keep[id] = true
+			continue
+		}
+
+		if id != "" {
+			reattached, err := reattachDataDisk(cs, d, id)
+			if err != nil {
+				return err
+			}
+			if reattached {
+				keep[id] = true
+				continue
+			}
+		}
+
+		id, err := createDataDisk(cs, d, d.Get("name").(string), zoneid, diskofferingid, i, m)
+		if err != nil {
+			return err
+		}
+		m["id"] = id
+		keep[id] = true
+	}
+
+	// Destroy the disks that were removed from the configuration
+	for _, disk := range o.([]interface{}) {
+		id := disk.(map[string]interface{})["id"].(string)
+		if _, ok := volumes[id]; !ok || keep[id] {
+			continue
+		}
+
+		log.Printf("[DEBUG] Destroying data disk %s of instance %s", id, d.Id())
+
+		p := cs.Volume.NewDetachVolumeParams()
+		p.SetId(id)
+		if _, err := cs.Volume.DetachVolume(p); err != nil {
+			return fmt.Errorf("Error detaching data disk %s: %s", id, err)
+		}
+
+		if _, err := cs.Volume.DeleteVolume(cs.Volume.NewDeleteVolumeParams(id)); err != nil {
+			return fmt.Errorf("Error deleting data disk %s: %s", id, err)
+		}
+	}
+
+	return d.Set("data_disk", disks)
+}
+
+// reattachDataDisk attaches a data disk that was detached from the instance
+// again, if it still exists and is not attached to another instance
+func reattachDataDisk(cs *cloudstack.CloudStackClient, d *schema.ResourceData, id string) (bool, error) {
+	v, count, err := cs.Volume.GetVolumeByID(id, cloudstack.WithProject(d.Get("project").(string)))
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Data disk %s of instance %s no longer exists", id, d.Id())
+			return false, nil
+		}
+		return false, err
+	}
+
+	if v.Virtualmachineid != "" {
+		log.Printf("[DEBUG] Data disk %s is attached to another instance %s", id, v.Virtualmachineid)
+		return false, nil
+	}
+
+	log.Printf("[DEBUG] Attaching data disk %s to instance %s again", id, d.Id())
+
+	p := cs.Volume.NewAttachVolumeParams(id, d.Id())
+	if _, err := Retry(10, retryableAttachVolumeFunc(cs, p)); err != nil {
+		return false, fmt.Errorf("Error attaching data disk %s: %s", id, err)
+	}
+
+	return true, nil
+}
+
+// resizeDataDisk changes the disk offering, size or IOPS of an attached data
+// disk when they differ from the configured values
+func resizeDataDisk(cs *cloudstack.CloudStackClient, v *cloudstack.Volume, diskofferingid string, m map[string]interface{}) error {
+	p := cs.Volume.NewResizeVolumeParams(v.Id)
+	resize := false
+
+	if v.Diskofferingid != diskofferingid {
+		p.SetDiskofferingid(diskofferingid)
+		resize = true
+	}
+
+	if size := m["size"].(int); size > 0 && int64(size) != v.Size>>30 {
+		p.SetSize(int64(size))
+		resize = true
+	}
+
+	if iops := m["min_iops"].(int); iops > 0 && int64(iops) != v.Miniops {
+		p.SetMiniops(int64(iops))
+		resize = true
+	}
+
+	if iops := m["max_iops"].(int); iops > 0 && int64(iops) != v.Maxiops {
+		p.SetMaxiops(int64(iops))
+		resize = true
+	}
+
+	if !resize {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Resizing data disk %s", v.Id)
+	if _, err := cs.Volume.ResizeVolume(p); err != nil {
+		return fmt.Errorf("Error resizing data disk %s: %s", v.Id, err)
+	}
+
+	return nil
+}
+
+// importDataDisks records the data disks that are attached to an imported
+// instance, in the order of their device IDs
+func importDataDisks(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	volumes, err := listDataDisks(cs, d)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(volumes, func(i, j int) bool {
+		return volumes[i].Deviceid < volumes[j].Deviceid
+	})
+
+	var disks []interface{}
+	for _, v := range volumes {
+		disks = append(disks, map[string]interface{}{
+			"disk_offering":        v.Diskofferingname,
+			"datadisk_template_id": v.Templateid,
+			"id":                   v.Id,
+		})
+	}
+
+	return d.Set("data_disk", disks)
+}
+
+// readDataDisks updates the data disks created with the instance. The disk
+// offering of disks that are no longer attached is cleared, so they show up
+// as a diff and are attached or created again on the next apply.
+func readDataDisks(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	if len(dataDiskIDs(d)) == 0 {
+		return nil
+	}
+
+	attached, err := listDataDisks(cs, d)
+	if err != nil {
+		return err
+	}
+
+	volumes := make(map[string]*cloudstack.Volume, len(attached))
+	for _, v := range attached {
+		volumes[v.Id] = v
+	}
+
+	var disks []interface{}
+	for _, disk := range d.Get("data_disk").([]interface{}) {
+		m := disk.(map[string]interface{})
+
+		// Keep the disks of which the ID could not be recorded as they are
+		if m["id"].(string) == "" {
+			disks = append(disks, m)
+			continue
+		}
+
+		v, ok := volumes[m["id"].(string)]
+		if !ok {
+			log.Printf("[DEBUG] Data disk %s is no longer attached to instance %s", m["id"], d.Id())
+			m["disk_offering"] = ""
+			disks = append(disks, m)
+			continue
+		}
+
+		if cloudstack.IsID(m["disk_offering"].(string)) {
+			m["disk_offering"] = v.Diskofferingid
+		} else {
+			m["disk_offering"] = v.Diskofferingname
+		}
+
+		// Only track the values that were explicitly set
+		if m["size"].(int) > 0 {
+			m["size"] = int(v.Size >> 30) // B to GiB
+		}
+		if m["min_iops"].(int) > 0 {
+			m["min_iops"] = int(v.Miniops)
+		}
+		if m["max_iops"].(int) > 0 {
+			m["max_iops"] = int(v.Maxiops)
+		}
+
+		disks = append(disks, m)
+	}
+
+	return d.Set("data_disk", disks)
+}
+
+// dataDiskIDs returns the IDs of the data disks created with the instance
+func dataDiskIDs(d *schema.ResourceData) map[string]bool {
+	ids := make(map[string]bool)
+	for _, disk := range d.Get("data_disk").([]interface{}) {
+		if id := disk.(map[string]interface{})["id"].(string); id != "" {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// listDataDisks returns the data disks that are attached to the instance
+func listDataDisks(cs *cloudstack.CloudStackClient, d *schema.ResourceData) ([]*cloudstack.Volume, error) {
+	p := cs.Volume.NewListVolumesParams()
+	p.SetType("DATADISK")
+	p.SetVirtualmachineid(d.Id())
+
+	if err := setProjectid(p, cs, d); err != nil {
+		return nil, err
+	}
+
+	l, err := cs.Volume.ListVolumes(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Volumes, nil
+}
+
+// resizeRootDisk grows the ROOT volume of the instance to the configured size
+func resizeRootDisk(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	// Create a new param struct.
+	p := cs.Volume.NewListVolumesParams()
+	p.SetType("ROOT")
+	p.SetVirtualmachineid(d.Id())
+
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Get the root disk of the instance.
+	l, err := cs.Volume.ListVolumes(p)
+	if err != nil {
+		return err
+	}
+
+	if len(l.Volumes) != 1 {
+		return fmt.Errorf("Failed to find root disk of instance %s", d.Id())
+	}
+
+	// Create a new parameter struct
+	rp := cs.Volume.NewResizeVolumeParams(l.Volumes[0].Id)
+	rp.SetSize(int64(d.Get("root_disk_size").(int)))
+
+	// Resize the root disk
+	if _, err := cs.Volume.ResizeVolume(rp); err != nil {
+		return fmt.Errorf("Error resizing the root disk of instance %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// requiresStoppedInstance returns true if the error says the instance needs
+// to be stopped to apply the change
+func requiresStoppedInstance(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "be stopped") || strings.Contains(msg, "stopped state")
+}
+
+// startInstance starts the instance, on the configured host if any
+func startInstance(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	p := cs.VirtualMachine.NewStartVirtualMachineParams(d.Id())
+
+	// The host is read back from the instance, so only pass it when it is
+	// actually configured and let CloudStack pick a host otherwise
+	if hostid, ok := d.GetOk("host_id"); ok {
+		if d.HasChange("host_id") || !d.GetRawConfig().GetAttr("host_id").IsNull() {
+			p.SetHostid(hostid.(string))
+		}
+	}
+
+	_, err := cs.VirtualMachine.StartVirtualMachine(p)
+	return err
+}
+
+// stopInstance stops the instance, forcing it to stop if configured
+func stopInstance(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	p := cs.VirtualMachine.NewStopVirtualMachineParams(d.Id())
+	p.SetForced(d.Get("force_stop").(bool))
+
+	_, err := cs.VirtualMachine.StopVirtualMachine(p)
+	return err
+}
+
+// migrateInstance moves the instance to the configured host and root disk
+// storage pool. A stopped instance only has its root disk migrated, as it is
+// started on the configured host afterwards.
+func migrateInstance(cs *cloudstack.CloudStackClient, d *schema.ResourceData, state string) error {
+	hostid := d.Get("host_id").(string)
+	poolid := d.Get("root_disk_storage_pool_id").(string)
+
+	if state == "Stopped" {
+		if !d.HasChange("root_disk_storage_pool_id") {
+			return nil
+		}
+
+		log.Printf("[DEBUG] Migrating the root disk of instance %s to storage pool %s", d.Id(), poolid)
+		p := cs.VirtualMachine.NewMigrateVirtualMachineParams(d.Id())
+		p.SetStorageid(poolid)
+
+		_, err := cs.VirtualMachine.MigrateVirtualMachine(p)
+		return err
+	}
+
+	vm, _, err := cs.VirtualMachine.GetVirtualMachineByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The instance may already be started on the new host
+	migrateHost := hostid != "" && vm.Hostid != hostid
+	if !migrateHost && !d.HasChange("root_disk_storage_pool_id") {
+		return nil
+	}
+
+	requiresStorageMotion := false
+	if migrateHost {
+		host, err := findMigrationHost(cs, d.Id(), hostid)
+		if err != nil {
+			return err
+		}
+		requiresStorageMotion = host.RequiresStorageMotion
+	}
+
+	if !requiresStorageMotion && !d.HasChange("root_disk_storage_pool_id") {
+		log.Printf("[DEBUG] Migrating instance %s to host %s", d.Id(), hostid)
+		p := cs.VirtualMachine.NewMigrateVirtualMachineParams(d.Id())
+		p.SetHostid(hostid)
+
+		_, err := cs.VirtualMachine.MigrateVirtualMachine(p)
+		return err
+	}
+
+	target := vm.Hostid
+	if migrateHost {
+		target = hostid
+	}
+
+	log.Printf("[DEBUG] Migrating instance %s with its volumes to host %s", d.Id(), target)
+	p := cs.VirtualMachine.NewMigrateVirtualMachineWithVolumeParams(d.Id())
+	p.SetHostid(target)
+
+	if d.HasChange("root_disk_storage_pool_id") {
+		lp := cs.Volume.NewListVolumesParams()
+		lp.SetType("ROOT")
+		lp.SetVirtualmachineid(d.Id())
+
+		l, err := cs.Volume.ListVolumes(lp)
+		if err != nil {
+			return err
+		}
+
+		if len(l.Volumes) != 1 {
+			return fmt.Errorf("Failed to find root disk of instance %s", d.Id())
+		}
+
+		p.AddMigrateto(map[string]string{
+			"volume": l.Volumes[0].Id,
+			"pool":   poolid,
+		})
+	}
+
+	_, err = cs.VirtualMachine.MigrateVirtualMachineWithVolume(p)
+	return err
+}
+
+// findMigrationHost returns the given host if the instance can be migrated to
+// it, or an error listing the hosts that are suitable instead
+func findMigrationHost(cs *cloudstack.CloudStackClient, vmid, hostid string) (*cloudstack.FindHostsForMigrationResponse, error) {
+	// The generated client expects a single host in the response, so use a
+	// custom request to get the full list of hosts
+	p := &cloudstack.CustomServiceParams{}
+	p.SetParam("virtualmachineid", vmid)
+
+	var r struct {
+		Count int                                         `json:"count"`
+		Hosts []*cloudstack.FindHostsForMigrationResponse `json:"host"`
+	}
+	custom, ok := cs.Custom.(*cloudstack.CustomService)
+	if !ok {
+		return nil, fmt.Errorf("Error finding hosts for migrating instance %s: unsupported client", vmid)
+	}
+
+	if err := custom.CustomRequest("findHostsForMigration", p, &r); err != nil {
+		return nil, fmt.Errorf("Error finding hosts for migrating instance %s: %s", vmid, err)
+	}
+
+	var suitable []string
+	for _, h := range r.Hosts {
+		if !h.Suitableformigration {
+			continue
+		}
+
+		if h.Id == hostid {
+			return h, nil
+		}
+
+		suitable = append(suitable, fmt.Sprintf("%s (%s)", h.Name, h.Id))
+	}
+
+	if len(suitable) == 0 {
+		return nil, fmt.Errorf(
+			"Instance %s cannot be migrated to host %s, no host is suitable for migration", vmid, hostid)
+	}
+
+	return nil, fmt.Errorf(
+		"Instance %s cannot be migrated to host %s, suitable hosts are: %s",
+		vmid, hostid, strings.Join(suitable, ", "))
+}
+
+func verifyInstanceParams(d *schema.ResourceData) error {
+	state := d.Get("state").(string)
+	if state != "" && state != "Running" && state != "Stopped" {
+		// The state is computed, so only validate configured values
+		if d.HasChange("state") {
+			return fmt.Errorf(
+				"%q is not a valid state. Valid options are 'Running' and 'Stopped'", state)
+		}
+	}
+
+	return nil
+}
+
+// serviceOfferingDetails returns the configured parameters of a custom
+// service offering
+func serviceOfferingDetails(d *schema.ResourceData) map[string]string {
+	details := make(map[string]string)
+
+	if v, ok := d.GetOk("cpu_number"); ok {
+		details["cpuNumber"] = strconv.Itoa(v.(int))
+	}
+
+	if v, ok := d.GetOk("cpu_speed"); ok {
+		details["cpuSpeed"] = strconv.Itoa(v.(int))
+	}
+
+	if v, ok := d.GetOk("memory"); ok {
+		details["memory"] = strconv.Itoa(v.(int))
+	}
+
+	return details
+}
+
+// scaleInstance changes the service offering of a running instance that is
+// dynamically scalable, or of a stopped instance. It returns false if the
+// instance needs to be stopped first.
+func scaleInstance(cs *cloudstack.CloudStackClient, d *schema.ResourceData) (bool, error) {
+	vm, _, err := cs.VirtualMachine.GetVirtualMachineByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// A stopped instance can be changed right away
+	if vm.State == "Stopped" {
+		return true, changeServiceForInstance(cs, d)
+	}
+
+	if !vm.Isdynamicallyscalable {
+		return false, nil
+	}
+
+	// Retrieve the service_offering ID
+	serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
+	if e != nil {
+		return false, e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.VirtualMachine.NewScaleVirtualMachineParams(d.Id(), serviceofferingid)
+
+	if details := serviceOfferingDetails(d); len(details) > 0 {
+		p.SetDetails(details)
+	}
+
+	// Scale the instance, the offering or hypervisor may still refuse to
+	// scale it live in which case we fall back to stopping it
+	if _, err := cs.VirtualMachine.ScaleVirtualMachine(p); err != nil {
+		log.Printf("[DEBUG] Failed to scale instance %s live: %s", d.Id(), err)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// changeServiceForInstance changes the service offering of a stopped instance
+func changeServiceForInstance(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	// Retrieve the service_offering ID
+	serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.VirtualMachine.NewChangeServiceForVirtualMachineParams(d.Id(), serviceofferingid)
+
+	if details := serviceOfferingDetails(d); len(details) > 0 {
+		p.SetDetails(details)
+	}
+
+	// Change the service offering
+	if _, err := cs.VirtualMachine.ChangeServiceForVirtualMachine(p); err != nil {
+		return fmt.Errorf(
+			"Error changing the service offering for instance %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+// retrieveInstanceGroupName returns the name of the instance group with the given ID
+func retrieveInstanceGroupName(cs *cloudstack.CloudStackClient, d *schema.ResourceData, groupid string) (string, error) {
+	g, _, err := cs.VMGroup.GetInstanceGroupByID(
+		groupid,
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving instance group %s: %s", groupid, err)
+	}
+
+	return g.Name, nil
+}
+
+// updateInstanceDetails updates the configured details of the instance. The
+// API replaces all details, so the details that are not managed by the details
+// argument are sent along unchanged.
+func updateInstanceDetails(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Details changed for %s, starting update", name)
+
+	vm, _, err := cs.VirtualMachine.GetVirtualMachineByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		return err
+	}
+
+	details := make(map[string]string, len(vm.Details))
+	for k, v := range vm.Details {
+		details[k] = v
+	}
+
+	o, n := d.GetChange("details")
+	for k := range o.(map[string]interface{}) {
+		delete(details, k)
+	}
+	for k, v := range n.(map[string]interface{}) {
+		details[k] = v.(string)
+	}
+
+	p := cs.VirtualMachine.NewUpdateVirtualMachineParams(d.Id())
+	if len(details) > 0 {
+		p.SetDetails(details)
+	} else {
+		p.SetCleanupdetails(true)
+	}
+
+	if _, err := cs.VirtualMachine.UpdateVirtualMachine(p); err != nil {
+		return fmt.Errorf(
+			"Error updating the details for instance %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// updateUserData updates the user data of the instance
+func updateUserData(cs *cloudstack.CloudStackClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] user_data changed for %s, starting update", name)
+
+	ud, err := getUserData(
+		d.Get("user_data").(string),
+		d.Get("user_data_base64").(bool),
+		d.Get("user_data_gzip").(bool),
+		cs.HTTPGETOnly,
+	)
+	if err != nil {
+		return err
+	}
+
+	p := cs.VirtualMachine.NewUpdateVirtualMachineParams(d.Id())
+	p.SetUserdata(ud)
+	_, err = cs.VirtualMachine.UpdateVirtualMachine(p)
+	if err != nil {
+		return fmt.Errorf(
+			"Error updating user_data for instance %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// getUserData returns the base64 encoded user data. Already encoded user data
+// is only validated, while plain text user data can be gzip compressed first.
+func getUserData(userData string, isBase64, compress, httpGetOnly bool) (string, error) {
+	ud := userData
+	if isBase64 {
+		if _, err := base64.StdEncoding.DecodeString(ud); err != nil {
+			return "", fmt.Errorf("The supplied user_data is not valid base64: %s", err)
+		}
+	} else {
+		data := []byte(userData)
+
+		if compress {
+			var buf bytes.Buffer
+			w := gzip.NewWriter(&buf)
+			if _, err := w.Write(data); err != nil {
+				return "", fmt.Errorf("Error compressing user_data: %s", err)
+			}
+			if err := w.Close(); err != nil {
+				return "", fmt.Errorf("Error compressing user_data: %s", err)
+			}
+			data = buf.Bytes()
+		}
+
+		ud = base64.StdEncoding.EncodeToString(data)
 	}
 
 	// deployVirtualMachine uses POST by default, so max userdata is 32K
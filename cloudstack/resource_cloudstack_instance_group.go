@@ -0,0 +1,177 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackInstanceGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackInstanceGroupCreate,
+		Read:   resourceCloudStackInstanceGroupRead,
+		Update: resourceCloudStackInstanceGroupUpdate,
+		Delete: resourceCloudStackInstanceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackInstanceGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.VMGroup.NewCreateInstanceGroupParams(name)
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Create the new instance group
+	r, err := cs.VMGroup.CreateInstanceGroup(p)
+	if err != nil {
+		return fmt.Errorf("Error creating instance group %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackInstanceGroupRead(d, meta)
+}
+
+func resourceCloudStackInstanceGroupRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the instance group details
+	g, count, err := cs.VMGroup.GetInstanceGroupByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Instance group %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", g.Name); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "project", g.Project, g.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackInstanceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+
+		log.Printf("[DEBUG] Name for instance group %s changed to %s, starting update", d.Id(), name)
+
+		// Create a new parameter struct
+		p := cs.VMGroup.NewUpdateInstanceGroupParams(d.Id())
+		p.SetName(name)
+
+		// Rename the instance group
+		_, err := cs.VMGroup.UpdateInstanceGroup(p)
+		if err != nil {
+			return fmt.Errorf("Error renaming instance group %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceCloudStackInstanceGroupRead(d, meta)
+}
+
+func resourceCloudStackInstanceGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Deleting a group silently ungroups its members, so refuse to do so
+	members, err := listInstanceGroupMembers(d, meta)
+	if err != nil {
+		return err
+	}
+
+	if len(members) > 0 {
+		return fmt.Errorf(
+			"Instance group %s still contains the instances %s, "+
+				"remove them from the group before deleting it", name, strings.Join(members, ", "))
+	}
+
+	// Create a new parameter struct
+	p := cs.VMGroup.NewDeleteInstanceGroupParams(d.Id())
+
+	// Delete the instance group
+	log.Printf("[INFO] Deleting instance group: %s", name)
+	_, err = cs.VMGroup.DeleteInstanceGroup(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting instance group %s: %s", name, err)
+	}
+
+	return nil
+}
+
+func listInstanceGroupMembers(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VirtualMachine.NewListVirtualMachinesParams()
+	p.SetGroupid(d.Id())
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return nil, err
+	}
+
+	l, err := cs.VirtualMachine.ListVirtualMachines(p)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error listing the members of instance group %s: %s", d.Get("name").(string), err)
+	}
+
+	var members []string
+	for _, vm := range l.VirtualMachines {
+		// Instances that are being removed are no longer relevant
+		if vm.State == "Destroyed" || vm.State == "Expunging" {
+			continue
+		}
+		members = append(members, vm.Name)
+	}
+
+	return members, nil
+}
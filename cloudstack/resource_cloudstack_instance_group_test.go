@@ -0,0 +1,165 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackInstanceGroup_basic(t *testing.T) {
+	var group cloudstack.InstanceGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstanceGroup_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceGroupExists(
+						"cloudstack_instance_group.foo", &group),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance_group.foo", "name", "terraform-group"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstanceGroup_update(t *testing.T) {
+	var group cloudstack.InstanceGroup
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstanceGroup_members("terraform-group"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceGroupExists(
+						"cloudstack_instance_group.foo", &group),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_instance.foobar", "group_id", "cloudstack_instance_group.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "group", "terraform-group"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstanceGroup_members("terraform-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceGroupExists(
+						"cloudstack_instance_group.foo", &group),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance_group.foo", "name", "terraform-renamed"),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_instance.foobar", "group_id", "cloudstack_instance_group.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstanceGroup_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstanceGroup_basic,
+			},
+
+			{
+				ResourceName:      "cloudstack_instance_group.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackInstanceGroupExists(
+	n string, group *cloudstack.InstanceGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No instance group ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		g, _, err := cs.VMGroup.GetInstanceGroupByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if g.Id != rs.Primary.ID {
+			return fmt.Errorf("Instance group not found")
+		}
+
+		*group = *g
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackInstanceGroupDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_instance_group" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No instance group ID is set")
+		}
+
+		_, _, err := cs.VMGroup.GetInstanceGroupByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Instance group %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackInstanceGroup_basic = `
+resource "cloudstack_instance_group" "foo" {
+  name = "terraform-group"
+}`
+
+func testAccCloudStackInstanceGroup_members(name string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_instance_group" "foo" {
+  name = "%s"
+}
+
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  group_id = "${cloudstack_instance_group.foo.id}"
+  expunge = true
+}`, name)
+}
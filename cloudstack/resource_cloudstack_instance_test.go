@@ -1,7 +1,14 @@
 package cloudstack
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/apache/cloudstack-go/v2/cloudstack"
@@ -193,6 +200,368 @@ func TestAccCloudStackInstance_importProject(t *testing.T) {
 	})
 }
 
+func TestAccCloudStackInstance_fromISO(t *testing.T) {
+	if CLOUDSTACK_ISO_URL == "" {
+		t.Skip("This test requires an ISO URL")
+	}
+
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_fromISO,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_instance.foobar", "iso", "cloudstack_iso.foo", "id"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "disk_offering", "Small"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_networkInterfaces(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_networkInterfaces,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "network_interface.#", "2"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "network_interface.0.ip_address", "10.1.1.123"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "network_interface.0.default", "true"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "network_interface.1.ip_address", "10.1.2.123"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_networkInterfacesUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "network_interface.#", "2"),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_instance.foobar", "network_interface.1.network_id",
+						"cloudstack_network.baz", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_disallowStop(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_disallowStop("terraform-test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+				),
+			},
+
+			{
+				Config:      testAccCloudStackInstance_disallowStop("terraform-updated"),
+				ExpectError: regexp.MustCompile("allow_stop_for_update is false"),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_state(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_state("Running"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "state", "Running"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_state("Stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "state", "Stopped"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_state("Running"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "state", "Running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_resizeRootDisk(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_resizeRootDisk(10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "root_disk_size", "10"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_resizeRootDisk(20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceNotRecreated(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "root_disk_size", "20"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_securityGroups(t *testing.T) {
+	if CLOUDSTACK_SECURITY_GROUP_ZONE == "" {
+		t.Skip("This test requires a zone with security groups enabled")
+	}
+
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_securityGroups(`"default"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "security_group_names.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_securityGroups(
+					`"default", cloudstack_security_group.foo.name`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceNotRecreated(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "security_group_names.#", "2"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "security_group_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_dataDisks(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+	var detached string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_dataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					testAccCheckCloudStackInstanceDataDisks(&instance, 2),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_instance.foobar", "data_disk.0.id"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_instance.foobar", "data_disk.1.id"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "state", "Running"),
+				),
+			},
+
+			{
+				PreConfig: func() {
+					detached = testAccDetachCloudStackInstanceDataDisk(t, &instance)
+				},
+				Config: testAccCloudStackInstance_dataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceNotRecreated(
+						"cloudstack_instance.foobar", &instance),
+					testAccCheckCloudStackInstanceDataDisks(&instance, 2),
+					resource.TestCheckResourceAttrPtr(
+						"cloudstack_instance.foobar", "data_disk.0.id", &detached),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_migrateUnsuitableHost(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_placement(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_instance.foobar", "host_id"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_instance.foobar", "root_disk_storage_pool_id"),
+				),
+			},
+
+			{
+				Config:      testAccCloudStackInstance_placement(`host_id = "00000000-0000-0000-0000-000000000000"`),
+				ExpectError: regexp.MustCompile("cannot be migrated to host"),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInstance_details(t *testing.T) {
+	var instance cloudstack.VirtualMachine
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInstance_details("us"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceExists(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "details.%", "1"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "details.keyboard", "us"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInstance_details("uk"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInstanceNotRecreated(
+						"cloudstack_instance.foobar", &instance),
+					resource.TestCheckResourceAttr(
+						"cloudstack_instance.foobar", "details.keyboard", "uk"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetUserData(t *testing.T) {
+	cases := []struct {
+		UserData          string
+		Base64, Gzip, Get bool
+		Expected          string
+		Err               bool
+	}{
+		// Plain text is always encoded, even if it looks like base64
+		{UserData: "abcd", Expected: "YWJjZA=="},
+
+		// Plain text is encoded even if it is already encoded
+		{UserData: "I2Nsb3VkLWNvbmZpZwo=", Expected: "STJOc2IzVmtMV052Ym1acFp3bz0="},
+
+		// Encoded user data is passed as is
+		{UserData: "YWJjZA==", Base64: true, Expected: "YWJjZA=="},
+
+		// Encoded user data must be valid base64
+		{UserData: "foo bar", Base64: true, Err: true},
+
+		// User data is limited to 2K when using GET requests
+		{UserData: strings.Repeat("a", 2048), Get: true, Err: true},
+		{UserData: strings.Repeat("a", 2048), Gzip: true, Get: true},
+	}
+
+	for i, tc := range cases {
+		ud, err := getUserData(tc.UserData, tc.Base64, tc.Gzip, tc.Get)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+		if tc.Expected != "" && ud != tc.Expected {
+			t.Fatalf("%d: bad user data: %s", i, ud)
+		}
+	}
+
+	ud, err := getUserData("foobar\nfoo\nbar", false, true, false)
+	if err != nil {
+		t.Fatalf("Error compressing user data: %s", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(ud)
+	if err != nil {
+		t.Fatalf("Error decoding user data: %s", err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error decompressing user data: %s", err)
+	}
+
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Error decompressing user data: %s", err)
+	}
+
+	if string(plain) != "foobar\nfoo\nbar" {
+		t.Fatalf("bad user data: %q", plain)
+	}
+}
+
 func testAccCheckCloudStackInstanceExists(
 	n string, instance *cloudstack.VirtualMachine) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -225,6 +594,77 @@ func testAccCheckCloudStackInstanceExists(
 	}
 }
 
+func testAccCheckCloudStackInstanceNotRecreated(
+	n string, instance *cloudstack.VirtualMachine) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID != instance.Id {
+			return fmt.Errorf("Instance was recreated: %s != %s", rs.Primary.ID, instance.Id)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackInstanceDataDisks(
+	instance *cloudstack.VirtualMachine, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+		p := cs.Volume.NewListVolumesParams()
+		p.SetType("DATADISK")
+		p.SetVirtualmachineid(instance.Id)
+
+		l, err := cs.Volume.ListVolumes(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count != count {
+			return fmt.Errorf("Bad number of data disks: %d", l.Count)
+		}
+
+		return nil
+	}
+}
+
+// testAccDetachCloudStackInstanceDataDisk detaches the first data disk of the
+// instance outside of Terraform and returns its ID
+func testAccDetachCloudStackInstanceDataDisk(
+	t *testing.T, instance *cloudstack.VirtualMachine) string {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	p := cs.Volume.NewListVolumesParams()
+	p.SetType("DATADISK")
+	p.SetVirtualmachineid(instance.Id)
+
+	l, err := cs.Volume.ListVolumes(p)
+	if err != nil {
+		t.Fatalf("Error listing data disks: %s", err)
+	}
+
+	if l.Count == 0 {
+		t.Fatal("No data disks found")
+	}
+
+	sort.SliceStable(l.Volumes, func(i, j int) bool {
+		return l.Volumes[i].Deviceid < l.Volumes[j].Deviceid
+	})
+
+	pd := cs.Volume.NewDetachVolumeParams()
+	pd.SetId(l.Volumes[0].Id)
+
+	if _, err := cs.Volume.DetachVolume(pd); err != nil {
+		t.Fatalf("Error detaching data disk %s: %s", l.Volumes[0].Id, err)
+	}
+
+	return l.Volumes[0].Id
+}
+
 func testAccCheckCloudStackInstanceAttributes(
 	instance *cloudstack.VirtualMachine) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -411,3 +851,253 @@ resource "cloudstack_instance" "foobar" {
   zone = "${cloudstack_network.foo.zone}"
   expunge = true
 }`
+
+var testAccCloudStackInstance_fromISO = fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_iso" "foo" {
+  name = "terraform-iso"
+  os_type = "Centos 5.6 (64-bit)"
+  url = "%s"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  iso = "${cloudstack_iso.foo.id}"
+  disk_offering = "Small"
+  hypervisor = "Simulator"
+  zone = "Sandbox-simulator"
+  expunge = true
+}`, CLOUDSTACK_ISO_URL)
+
+const testAccCloudStackInstance_networkInterfaces = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_network" "bar" {
+  name = "terraform-network-bar"
+  cidr = "10.1.2.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform-test"
+  service_offering= "Small Instance"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+
+  network_interface {
+    network_id = "${cloudstack_network.foo.id}"
+    ip_address = "10.1.1.123"
+    default = true
+  }
+
+  network_interface {
+    network_id = "${cloudstack_network.bar.id}"
+    ip_address = "10.1.2.123"
+  }
+}`
+
+const testAccCloudStackInstance_networkInterfacesUpdated = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_network" "bar" {
+  name = "terraform-network-bar"
+  cidr = "10.1.2.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_network" "baz" {
+  name = "terraform-network-baz"
+  cidr = "10.1.3.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform-test"
+  service_offering= "Small Instance"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+
+  network_interface {
+    network_id = "${cloudstack_network.foo.id}"
+    ip_address = "10.1.1.123"
+    default = true
+  }
+
+  network_interface {
+    network_id = "${cloudstack_network.baz.id}"
+  }
+}`
+
+func testAccCloudStackInstance_disallowStop(name string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "%s"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  allow_stop_for_update = false
+  expunge = true
+}`, name)
+}
+
+func testAccCloudStackInstance_state(state string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  state = "%s"
+  force_stop = true
+  expunge = true
+}`, state)
+}
+
+func testAccCloudStackInstance_resizeRootDisk(size int) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  root_disk_size = %d
+  resize_root_disk = true
+  expunge = true
+}`, size)
+}
+
+func testAccCloudStackInstance_securityGroups(groups string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_security_group" "foo" {
+  name = "terraform-security-group"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "%s"
+  security_group_names = [%s]
+  stop_for_security_group_update = true
+  expunge = true
+}`, CLOUDSTACK_SECURITY_GROUP_ZONE, groups)
+}
+
+const testAccCloudStackInstance_dataDisks = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+
+  data_disk {
+    disk_offering = "Small"
+  }
+
+  data_disk {
+    disk_offering = "Medium"
+  }
+}`
+
+func testAccCloudStackInstance_placement(placement string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  %s
+  expunge = true
+}`, placement)
+}
+
+func testAccCloudStackInstance_details(keyboard string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+
+  details = {
+    keyboard = "%s"
+  }
+}`, keyboard)
+}
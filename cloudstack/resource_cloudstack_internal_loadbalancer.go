@@ -0,0 +1,270 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackInternalLoadBalancer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackInternalLoadBalancerCreate,
+		Read:   resourceCloudStackInternalLoadBalancerRead,
+		Update: resourceCloudStackInternalLoadBalancerUpdate,
+		Delete: resourceCloudStackInternalLoadBalancerDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"network_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_network_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"source_ip_address": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"source_port": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"instance_port": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"algorithm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"member_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackInternalLoadBalancerCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+	networkid := d.Get("network_id").(string)
+
+	// The source IP address is taken from the same network by default
+	sourcenetworkid := networkid
+	if v, ok := d.GetOk("source_network_id"); ok {
+		sourcenetworkid = v.(string)
+	}
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewCreateLoadBalancerParams(
+		d.Get("algorithm").(string),
+		d.Get("instance_port").(int),
+		name,
+		networkid,
+		"Internal",
+		sourcenetworkid,
+		d.Get("source_port").(int),
+	)
+
+	// Set the description
+	if description, ok := d.GetOk("description"); ok {
+		p.SetDescription(description.(string))
+	} else {
+		p.SetDescription(name)
+	}
+
+	if ipaddress, ok := d.GetOk("source_ip_address"); ok {
+		p.SetSourceipaddress(ipaddress.(string))
+	}
+
+	// Create the internal load balancer
+	r, err := cs.LoadBalancer.CreateLoadBalancer(p)
+	if err != nil {
+		return fmt.Errorf("Error creating internal load balancer %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// Create a new parameter struct
+	mp := cs.LoadBalancer.NewAssignToLoadBalancerRuleParams(r.Id)
+
+	var mbs []string
+	for _, id := range d.Get("member_ids").(*schema.Set).List() {
+		mbs = append(mbs, id.(string))
+	}
+
+	mp.SetVirtualmachineids(mbs)
+
+	if _, err := cs.LoadBalancer.AssignToLoadBalancerRule(mp); err != nil {
+		return fmt.Errorf("Error assigning members to internal load balancer %s: %s", name, err)
+	}
+
+	return resourceCloudStackInternalLoadBalancerRead(d, meta)
+}
+
+func resourceCloudStackInternalLoadBalancerRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the internal load balancer details
+	lb, count, err := cs.LoadBalancer.GetLoadBalancerByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Internal load balancer %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", lb.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", lb.Description); err != nil {
+		return err
+	}
+	if err := d.Set("network_id", lb.Networkid); err != nil {
+		return err
+	}
+	if err := d.Set("source_network_id", lb.Sourceipaddressnetworkid); err != nil {
+		return err
+	}
+	if err := d.Set("source_ip_address", lb.Sourceipaddress); err != nil {
+		return err
+	}
+	if err := d.Set("algorithm", lb.Algorithm); err != nil {
+		return err
+	}
+
+	if len(lb.Loadbalancerrule) > 0 {
+		if err := d.Set("source_port", lb.Loadbalancerrule[0].Sourceport); err != nil {
+			return err
+		}
+		if err := d.Set("instance_port", lb.Loadbalancerrule[0].Instanceport); err != nil {
+			return err
+		}
+	}
+
+	var mbs []string
+	for _, i := range lb.Loadbalancerinstance {
+		mbs = append(mbs, i.Id)
+	}
+	if err := d.Set("member_ids", mbs); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "project", lb.Project, lb.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackInternalLoadBalancerUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if d.HasChange("member_ids") {
+		o, n := d.GetChange("member_ids")
+		ombs, nmbs := o.(*schema.Set), n.(*schema.Set)
+
+		setToStringList := func(s *schema.Set) []string {
+			l := make([]string, s.Len())
+			for i, v := range s.List() {
+				l[i] = v.(string)
+			}
+			return l
+		}
+
+		membersToAdd := setToStringList(nmbs.Difference(ombs))
+		membersToRemove := setToStringList(ombs.Difference(nmbs))
+
+		log.Printf("[DEBUG] Members to add: %v, remove: %v", membersToAdd, membersToRemove)
+
+		if len(membersToAdd) > 0 {
+			p := cs.LoadBalancer.NewAssignToLoadBalancerRuleParams(d.Id())
+			p.SetVirtualmachineids(membersToAdd)
+			if _, err := cs.LoadBalancer.AssignToLoadBalancerRule(p); err != nil {
+				return err
+			}
+		}
+
+		if len(membersToRemove) > 0 {
+			p := cs.LoadBalancer.NewRemoveFromLoadBalancerRuleParams(d.Id())
+			p.SetVirtualmachineids(membersToRemove)
+			if _, err := cs.LoadBalancer.RemoveFromLoadBalancerRule(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceCloudStackInternalLoadBalancerRead(d, meta)
+}
+
+func resourceCloudStackInternalLoadBalancerDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewDeleteLoadBalancerParams(d.Id())
+
+	log.Printf("[INFO] Deleting internal load balancer: %s", d.Get("name").(string))
+	if _, err := cs.LoadBalancer.DeleteLoadBalancer(p); err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if !strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return fmt.Errorf("Error deleting internal load balancer %s: %s", d.Get("name").(string), err)
+		}
+	}
+
+	return nil
+}
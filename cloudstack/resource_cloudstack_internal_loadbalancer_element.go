@@ -0,0 +1,137 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackInternalLoadBalancerElement() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackInternalLoadBalancerElementCreate,
+		Read:   resourceCloudStackInternalLoadBalancerElementRead,
+		Update: resourceCloudStackInternalLoadBalancerElementUpdate,
+		Delete: resourceCloudStackInternalLoadBalancerElementDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"nsp_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackInternalLoadBalancerElementCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	nspid := d.Get("nsp_id").(string)
+
+	// Adding the network service provider usually also creates the element
+	p := cs.InternalLB.NewListInternalLoadBalancerElementsParams()
+	p.SetNspid(nspid)
+
+	l, err := cs.InternalLB.ListInternalLoadBalancerElements(p)
+	if err != nil {
+		return err
+	}
+
+	if l.Count > 0 {
+		d.SetId(l.InternalLoadBalancerElements[0].Id)
+	} else {
+		// Create a new parameter struct
+		cp := cs.InternalLB.NewCreateInternalLoadBalancerElementParams(nspid)
+
+		// Create the element
+		r, err := cs.InternalLB.CreateInternalLoadBalancerElement(cp)
+		if err != nil {
+			return fmt.Errorf(
+				"Error creating internal load balancer element for provider %s: %s", nspid, err)
+		}
+
+		d.SetId(r.Id)
+	}
+
+	if err := resourceCloudStackInternalLoadBalancerElementConfigure(d, meta); err != nil {
+		return err
+	}
+
+	return resourceCloudStackInternalLoadBalancerElementRead(d, meta)
+}
+
+func resourceCloudStackInternalLoadBalancerElementRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the element details
+	e, count, err := cs.InternalLB.GetInternalLoadBalancerElementByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Internal load balancer element %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("nsp_id", e.Nspid); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", e.Enabled); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackInternalLoadBalancerElementUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("enabled") {
+		if err := resourceCloudStackInternalLoadBalancerElementConfigure(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackInternalLoadBalancerElementRead(d, meta)
+}
+
+func resourceCloudStackInternalLoadBalancerElementDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Elements cannot be deleted, so the best we can do is disable it
+	p := cs.InternalLB.NewConfigureInternalLoadBalancerElementParams(false, d.Id())
+
+	log.Printf("[INFO] Disabling internal load balancer element: %s", d.Id())
+	if _, err := cs.InternalLB.ConfigureInternalLoadBalancerElement(p); err != nil {
+		return fmt.Errorf("Error disabling internal load balancer element %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceCloudStackInternalLoadBalancerElementConfigure(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	enabled := d.Get("enabled").(bool)
+
+	// Create a new parameter struct
+	p := cs.InternalLB.NewConfigureInternalLoadBalancerElementParams(enabled, d.Id())
+
+	log.Printf("[DEBUG] Configuring internal load balancer element %s (enabled: %t)", d.Id(), enabled)
+	if _, err := cs.InternalLB.ConfigureInternalLoadBalancerElement(p); err != nil {
+		return fmt.Errorf("Error configuring internal load balancer element %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
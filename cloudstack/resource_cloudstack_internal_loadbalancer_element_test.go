@@ -0,0 +1,81 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackInternalLoadBalancerElement_basic(t *testing.T) {
+	if CLOUDSTACK_INTERNAL_LB_NSP_ID == "" {
+		t.Skip("This test requires the ID of an InternalLbVm network service provider")
+	}
+
+	var element cloudstack.InternalLoadBalancerElement
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInternalLoadBalancerElement_basic(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInternalLoadBalancerElementExists(
+						"cloudstack_internal_loadbalancer_element.foo", &element),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer_element.foo", "enabled", "true"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInternalLoadBalancerElement_basic(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInternalLoadBalancerElementExists(
+						"cloudstack_internal_loadbalancer_element.foo", &element),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer_element.foo", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackInternalLoadBalancerElementExists(
+	n string, element *cloudstack.InternalLoadBalancerElement) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No internal load balancer element ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		e, _, err := cs.InternalLB.GetInternalLoadBalancerElementByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if e.Id != rs.Primary.ID {
+			return fmt.Errorf("Internal load balancer element not found")
+		}
+
+		*element = *e
+
+		return nil
+	}
+}
+
+func testAccCloudStackInternalLoadBalancerElement_basic(enabled bool) string {
+	return fmt.Sprintf(`
+resource "cloudstack_internal_loadbalancer_element" "foo" {
+  nsp_id = "%s"
+  enabled = %t
+}`, CLOUDSTACK_INTERNAL_LB_NSP_ID, enabled)
+}
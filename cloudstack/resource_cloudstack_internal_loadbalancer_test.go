@@ -0,0 +1,205 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackInternalLoadBalancer_basic(t *testing.T) {
+	var lb cloudstack.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInternalLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInternalLoadBalancer_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInternalLoadBalancerExists(
+						"cloudstack_internal_loadbalancer.foo", &lb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "name", "terraform-ilb"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "source_ip_address", "10.1.1.200"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "source_port", "80"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "instance_port", "8080"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "member_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackInternalLoadBalancer_update(t *testing.T) {
+	var lb cloudstack.LoadBalancer
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackInternalLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackInternalLoadBalancer_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInternalLoadBalancerExists(
+						"cloudstack_internal_loadbalancer.foo", &lb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "member_ids.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackInternalLoadBalancer_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackInternalLoadBalancerExists(
+						"cloudstack_internal_loadbalancer.foo", &lb),
+					resource.TestCheckResourceAttr(
+						"cloudstack_internal_loadbalancer.foo", "member_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackInternalLoadBalancerExists(
+	n string, lb *cloudstack.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No internal load balancer ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		l, _, err := cs.LoadBalancer.GetLoadBalancerByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if l.Id != rs.Primary.ID {
+			return fmt.Errorf("Internal load balancer not found")
+		}
+
+		*lb = *l
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackInternalLoadBalancerDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_internal_loadbalancer" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No internal load balancer ID is set")
+		}
+
+		_, _, err := cs.LoadBalancer.GetLoadBalancerByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Internal load balancer %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackInternalLoadBalancer_basic = `
+resource "cloudstack_vpc" "foo" {
+  name = "terraform-vpc"
+  cidr = "10.0.0.0/8"
+  vpc_offering = "Default VPC offering"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingForVpcNetworksWithInternalLB"
+  vpc_id = "${cloudstack_vpc.foo.id}"
+  zone = "${cloudstack_vpc.foo.zone}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_internal_loadbalancer" "foo" {
+  name = "terraform-ilb"
+  network_id = "${cloudstack_network.foo.id}"
+  source_ip_address = "10.1.1.200"
+  source_port = 80
+  instance_port = 8080
+  algorithm = "roundrobin"
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+}`
+
+const testAccCloudStackInternalLoadBalancer_update = `
+resource "cloudstack_vpc" "foo" {
+  name = "terraform-vpc"
+  cidr = "10.0.0.0/8"
+  vpc_offering = "Default VPC offering"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingForVpcNetworksWithInternalLB"
+  vpc_id = "${cloudstack_vpc.foo.id}"
+  zone = "${cloudstack_vpc.foo.zone}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_instance" "foobar2" {
+  name = "terraform-server2"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_internal_loadbalancer" "foo" {
+  name = "terraform-ilb"
+  network_id = "${cloudstack_network.foo.id}"
+  source_ip_address = "10.1.1.200"
+  source_port = 80
+  instance_port = 8080
+  algorithm = "roundrobin"
+  member_ids = [
+    "${cloudstack_instance.foobar1.id}",
+    "${cloudstack_instance.foobar2.id}",
+  ]
+}`
@@ -0,0 +1,380 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackISO() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackISOCreate,
+		Read:   resourceCloudStackISORead,
+		Update: resourceCloudStackISOUpdate,
+		Delete: resourceCloudStackISODelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"display_text": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"checksum": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"bootable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"os_type": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"cross_zones": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_extractable": {
+				Type:       schema.TypeBool,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"is_featured": {
+				Type:       schema.TypeBool,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"is_public": {
+				Type:       schema.TypeBool,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"is_ready": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_ready_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  300,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceCloudStackISOCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Compute/set the display text
+	displaytext := d.Get("display_text").(string)
+	if displaytext == "" {
+		displaytext = name
+	}
+
+	// Retrieve the zone ID, or register the ISO in all zones
+	zoneid := "-1"
+	if v, ok := d.GetOk("zone"); ok {
+		var e *retrieveError
+		zoneid, e = retrieveID(cs, "zone", v.(string))
+		if e != nil {
+			return e.Error()
+		}
+	}
+
+	// Create a new parameter struct
+	p := cs.ISO.NewRegisterIsoParams(displaytext, name, d.Get("url").(string), zoneid)
+	p.SetBootable(d.Get("bootable").(bool))
+
+	// Retrieve the os_type ID
+	if v, ok := d.GetOk("os_type"); ok {
+		ostypeid, e := retrieveID(cs, "os_type", v.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetOstypeid(ostypeid)
+	}
+
+	if v, ok := d.GetOk("checksum"); ok {
+		p.SetChecksum(v.(string))
+	}
+
+	if v, ok := d.GetOk("is_extractable"); ok {
+		p.SetIsextractable(v.(bool))
+	}
+
+	if v, ok := d.GetOk("is_featured"); ok {
+		p.SetIsfeatured(v.(bool))
+	}
+
+	if v, ok := d.GetOk("is_public"); ok {
+		p.SetIspublic(v.(bool))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Register the new ISO
+	r, err := cs.ISO.RegisterIso(p)
+	if err != nil {
+		return fmt.Errorf("Error registering ISO %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// Set tags if necessary
+	if err = setTags(cs, d, "ISO"); err != nil {
+		return fmt.Errorf("Error setting tags on the ISO %s: %s", name, err)
+	}
+
+	// Wait until the ISO is ready to use, or timeout with an error...
+	currentTime := time.Now().Unix()
+	timeout := int64(d.Get("is_ready_timeout").(int))
+	for {
+		// Start with the sleep so the register action has a few seconds
+		// to process the registration correctly. Without this wait
+		time.Sleep(10 * time.Second)
+
+		err := resourceCloudStackISORead(d, meta)
+		if err != nil {
+			return err
+		}
+
+		if d.Get("is_ready").(bool) {
+			return nil
+		}
+
+		if time.Now().Unix()-currentTime > timeout {
+			return fmt.Errorf("Timeout while waiting for ISO to become ready")
+		}
+	}
+}
+
+func resourceCloudStackISORead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.ISO.NewListIsosParams()
+	p.SetId(d.Id())
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Get the ISO details, a cross-zone ISO is listed once for every zone
+	l, err := cs.ISO.ListIsos(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			log.Printf("[DEBUG] ISO %s no longer exists", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] ISO %s no longer exists", d.Get("name").(string))
+		d.SetId("")
+		return nil
+	}
+
+	iso := l.Isos[0]
+
+	// The ISO is only ready when it is ready in all zones
+	ready := true
+	for _, i := range l.Isos {
+		ready = ready && i.Isready
+	}
+
+	if err = d.Set("name", iso.Name); err != nil {
+		return err
+	}
+	if err = d.Set("display_text", iso.Displaytext); err != nil {
+		return err
+	}
+	if err = d.Set("bootable", iso.Bootable); err != nil {
+		return err
+	}
+	if err = d.Set("cross_zones", iso.CrossZones); err != nil {
+		return err
+	}
+	if err = d.Set("is_extractable", iso.Isextractable); err != nil {
+		return err
+	}
+	if err = d.Set("is_featured", iso.Isfeatured); err != nil {
+		return err
+	}
+	if err = d.Set("is_public", iso.Ispublic); err != nil {
+		return err
+	}
+	if err = d.Set("is_ready", ready); err != nil {
+		return err
+	}
+
+	tags := make(map[string]interface{})
+	for _, tag := range iso.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	if err = d.Set("tags", tags); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "os_type", iso.Ostypename, iso.Ostypeid)
+	setValueOrID(d, "project", iso.Project, iso.Projectid)
+
+	// Only set the zone if the ISO is not available in all zones
+	if !iso.CrossZones {
+		setValueOrID(d, "zone", iso.Zonename, iso.Zoneid)
+	}
+
+	return nil
+}
+
+func resourceCloudStackISOUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+	name := d.Get("name").(string)
+
+	if d.HasChange("name") || d.HasChange("display_text") ||
+		d.HasChange("bootable") || d.HasChange("os_type") {
+		// Create a new parameter struct
+		p := cs.ISO.NewUpdateIsoParams(d.Id())
+
+		if d.HasChange("name") {
+			p.SetName(name)
+		}
+
+		if d.HasChange("display_text") {
+			p.SetDisplaytext(d.Get("display_text").(string))
+		}
+
+		if d.HasChange("bootable") {
+			p.SetBootable(d.Get("bootable").(bool))
+		}
+
+		if d.HasChange("os_type") {
+			ostypeid, e := retrieveID(cs, "os_type", d.Get("os_type").(string))
+			if e != nil {
+				return e.Error()
+			}
+			p.SetOstypeid(ostypeid)
+		}
+
+		_, err := cs.ISO.UpdateIso(p)
+		if err != nil {
+			return fmt.Errorf("Error updating ISO %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("is_extractable") || d.HasChange("is_featured") || d.HasChange("is_public") {
+		// Create a new parameter struct
+		p := cs.ISO.NewUpdateIsoPermissionsParams(d.Id())
+
+		if d.HasChange("is_extractable") {
+			p.SetIsextractable(d.Get("is_extractable").(bool))
+		}
+
+		if d.HasChange("is_featured") {
+			p.SetIsfeatured(d.Get("is_featured").(bool))
+		}
+
+		if d.HasChange("is_public") {
+			p.SetIspublic(d.Get("is_public").(bool))
+		}
+
+		_, err := cs.ISO.UpdateIsoPermissions(p)
+		if err != nil {
+			return fmt.Errorf("Error updating the permissions of ISO %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("tags") {
+		if err := updateTags(cs, d, "ISO"); err != nil {
+			return fmt.Errorf("Error updating tags on ISO %s: %s", name, err)
+		}
+	}
+
+	return resourceCloudStackISORead(d, meta)
+}
+
+func resourceCloudStackISODelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.ISO.NewDeleteIsoParams(d.Id())
+
+	// Delete the ISO
+	log.Printf("[INFO] Deleting ISO: %s", d.Get("name").(string))
+	_, err := cs.ISO.DeleteIso(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting ISO %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackISOAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackISOAttachmentCreate,
+		Read:   resourceCloudStackISOAttachmentRead,
+		Delete: resourceCloudStackISOAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"iso_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"virtual_machine_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackISOAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	isoid := d.Get("iso_id").(string)
+	virtualmachineid := d.Get("virtual_machine_id").(string)
+
+	// Create a new parameter struct
+	p := cs.ISO.NewAttachIsoParams(isoid, virtualmachineid)
+
+	// Attach the ISO
+	log.Printf("[DEBUG] Attaching ISO %s to instance %s", isoid, virtualmachineid)
+	if _, err := cs.ISO.AttachIso(p); err != nil {
+		return fmt.Errorf(
+			"Error attaching ISO %s to instance %s: %s", isoid, virtualmachineid, err)
+	}
+
+	// An instance can only have a single ISO attached, so use the instance ID
+	d.SetId(virtualmachineid)
+
+	return resourceCloudStackISOAttachmentRead(d, meta)
+}
+
+func resourceCloudStackISOAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the virtual machine details
+	vm, count, err := cs.VirtualMachine.GetVirtualMachineByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Instance %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if vm.Isoid != d.Get("iso_id").(string) {
+		log.Printf("[DEBUG] ISO %s is no longer attached to instance %s",
+			d.Get("iso_id").(string), d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("virtual_machine_id", vm.Id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackISOAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.ISO.NewDetachIsoParams(d.Id())
+
+	// Detach the ISO
+	log.Printf("[INFO] Detaching ISO %s from instance %s", d.Get("iso_id").(string), d.Id())
+	if _, err := cs.ISO.DetachIso(p); err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"Error detaching ISO %s from instance %s: %s", d.Get("iso_id").(string), d.Id(), err)
+	}
+
+	return nil
+}
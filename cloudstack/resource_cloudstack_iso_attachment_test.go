@@ -0,0 +1,107 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackISOAttachment_basic(t *testing.T) {
+	if CLOUDSTACK_ISO_URL == "" {
+		t.Skip("This test requires an ISO URL")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackISOAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackISOAttachment_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackISOAttachmentExists("cloudstack_iso_attachment.foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackISOAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ISO attachment ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		vm, _, err := cs.VirtualMachine.GetVirtualMachineByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if vm.Isoid != rs.Primary.Attributes["iso_id"] {
+			return fmt.Errorf("ISO not attached")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackISOAttachmentDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_iso_attachment" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ISO attachment ID is set")
+		}
+
+		vm, _, err := cs.VirtualMachine.GetVirtualMachineByID(rs.Primary.ID)
+		if err == nil && vm.Isoid == rs.Primary.Attributes["iso_id"] {
+			return fmt.Errorf("ISO %s is still attached", rs.Primary.Attributes["iso_id"])
+		}
+	}
+
+	return nil
+}
+
+var testAccCloudStackISOAttachment_basic = fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  display_name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+}
+
+resource "cloudstack_iso" "foo" {
+  name = "terraform-iso"
+  os_type = "Centos 5.6 (64-bit)"
+  url = "%s"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_iso_attachment" "foo" {
+  iso_id = "${cloudstack_iso.foo.id}"
+  virtual_machine_id = "${cloudstack_instance.foobar.id}"
+}`, CLOUDSTACK_ISO_URL)
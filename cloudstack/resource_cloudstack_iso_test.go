@@ -0,0 +1,149 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackISO_basic(t *testing.T) {
+	if CLOUDSTACK_ISO_URL == "" {
+		t.Skip("This test requires an ISO URL")
+	}
+
+	var iso cloudstack.Iso
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackISODestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackISO_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackISOExists("cloudstack_iso.foo", &iso),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "name", "terraform-iso"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "display_text", "terraform-iso"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "bootable", "true"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "is_ready", "true"),
+					testAccCheckResourceTags(&iso),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackISO_update(t *testing.T) {
+	if CLOUDSTACK_ISO_URL == "" {
+		t.Skip("This test requires an ISO URL")
+	}
+
+	var iso cloudstack.Iso
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackISODestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackISO_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackISOExists("cloudstack_iso.foo", &iso),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "display_text", "terraform-iso"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackISO_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackISOExists("cloudstack_iso.foo", &iso),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "display_text", "terraform-updated"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_iso.foo", "is_extractable", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackISOExists(n string, iso *cloudstack.Iso) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ISO ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		i, _, err := cs.ISO.GetIsoByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if i.Id != rs.Primary.ID {
+			return fmt.Errorf("ISO not found")
+		}
+
+		*iso = *i
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackISODestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_iso" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ISO ID is set")
+		}
+
+		_, _, err := cs.ISO.GetIsoByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("ISO %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccCloudStackISO_basic = fmt.Sprintf(`
+resource "cloudstack_iso" "foo" {
+  name = "terraform-iso"
+  os_type = "Centos 5.6 (64-bit)"
+  url = "%s"
+  zone = "Sandbox-simulator"
+  tags = {
+    terraform-tag = "true"
+  }
+}`, CLOUDSTACK_ISO_URL)
+
+var testAccCloudStackISO_update = fmt.Sprintf(`
+resource "cloudstack_iso" "foo" {
+  name = "terraform-iso"
+  display_text = "terraform-updated"
+  os_type = "Centos 5.6 (64-bit)"
+  url = "%s"
+  zone = "Sandbox-simulator"
+  is_extractable = true
+  tags = {
+    terraform-tag = "true"
+  }
+}`, CLOUDSTACK_ISO_URL)
@@ -0,0 +1,417 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackKubernetesCluster() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackKubernetesClusterCreate,
+		Read:   resourceCloudStackKubernetesClusterRead,
+		Update: resourceCloudStackKubernetesClusterUpdate,
+		Delete: resourceCloudStackKubernetesClusterDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"kubernetes_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"service_offering": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"control_nodes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ForceNew: true,
+			},
+
+			"network_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"keypair": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"node_root_disk_size": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"kube_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackKubernetesClusterCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyKubernetesClusterParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Compute/set the description
+	description := d.Get("description").(string)
+	if description == "" {
+		description = name
+	}
+
+	// Retrieve the kubernetes_version ID
+	versionid, e := retrieveID(cs, "kubernetes_version", d.Get("kubernetes_version").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Retrieve the service_offering ID
+	serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewCreateKubernetesClusterParams(
+		description,
+		versionid,
+		name,
+		serviceofferingid,
+		int64(d.Get("size").(int)),
+		zoneid,
+	)
+
+	p.SetControlnodes(int64(d.Get("control_nodes").(int)))
+
+	if networkid, ok := d.GetOk("network_id"); ok {
+		p.SetNetworkid(networkid.(string))
+	}
+
+	if keypair, ok := d.GetOk("keypair"); ok {
+		p.SetKeypair(keypair.(string))
+	}
+
+	if rootdisksize, ok := d.GetOk("node_root_disk_size"); ok {
+		p.SetNoderootdisksize(int64(rootdisksize.(int)))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Create the new Kubernetes cluster
+	r, err := cs.Kubernetes.CreateKubernetesCluster(p)
+	if err != nil {
+		return fmt.Errorf("Error creating Kubernetes cluster %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// A new cluster is always started, so stop it again if requested
+	if d.Get("state").(string) == "Stopped" {
+		if err := resourceCloudStackKubernetesClusterStop(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackKubernetesClusterRead(d, meta)
+}
+
+func resourceCloudStackKubernetesClusterRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the Kubernetes cluster details
+	c, count, err := cs.Kubernetes.GetKubernetesClusterByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Kubernetes cluster %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", c.Name); err != nil {
+		return err
+	}
+	if err := d.Set("description", c.Description); err != nil {
+		return err
+	}
+	if err := d.Set("size", int(c.Size)); err != nil {
+		return err
+	}
+	if err := d.Set("control_nodes", int(c.Controlnodes)); err != nil {
+		return err
+	}
+	if err := d.Set("network_id", c.Networkid); err != nil {
+		return err
+	}
+	if err := d.Set("keypair", c.Keypair); err != nil {
+		return err
+	}
+	if err := d.Set("state", c.State); err != nil {
+		return err
+	}
+	if err := d.Set("ip_address", c.Ipaddress); err != nil {
+		return err
+	}
+	if err := d.Set("endpoint", c.Endpoint); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "kubernetes_version", c.Kubernetesversionname, c.Kubernetesversionid)
+	setValueOrID(d, "service_offering", c.Serviceofferingname, c.Serviceofferingid)
+	setValueOrID(d, "project", c.Project, c.Projectid)
+	setValueOrID(d, "zone", c.Zonename, c.Zoneid)
+
+	// The kubeconfig can only be retrieved while the cluster is running
+	if c.State == "Running" {
+		p := cs.Kubernetes.NewGetKubernetesClusterConfigParams()
+		p.SetId(d.Id())
+
+		r, err := cs.Kubernetes.GetKubernetesClusterConfig(p)
+		if err != nil {
+			return fmt.Errorf(
+				"Error retrieving the config of Kubernetes cluster %s: %s", c.Name, err)
+		}
+
+		if err := d.Set("kube_config", r.Configdata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudStackKubernetesClusterUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyKubernetesClusterParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	state := d.Get("state").(string)
+
+	// A stopped cluster cannot be scaled or upgraded, so start it first
+	if d.HasChange("state") && state == "Running" {
+		if err := resourceCloudStackKubernetesClusterStart(d, meta); err != nil {
+			return err
+		}
+	}
+
+	// Check if the Kubernetes version has changed and if so, upgrade the cluster
+	if d.HasChange("kubernetes_version") {
+		log.Printf("[DEBUG] Kubernetes version changed for %s, starting upgrade", name)
+
+		// Retrieve the kubernetes_version ID
+		versionid, e := retrieveID(cs, "kubernetes_version", d.Get("kubernetes_version").(string))
+		if e != nil {
+			return e.Error()
+		}
+
+		// Create a new parameter struct
+		p := cs.Kubernetes.NewUpgradeKubernetesClusterParams(d.Id(), versionid)
+
+		// Upgrade the cluster
+		_, err := cs.Kubernetes.UpgradeKubernetesCluster(p)
+		if err != nil {
+			return fmt.Errorf(
+				"Error upgrading Kubernetes cluster %s: %s", name, err)
+		}
+	}
+
+	// Check if the size or the service offering has changed and if so, scale the cluster
+	if d.HasChange("size") || d.HasChange("service_offering") {
+		log.Printf("[DEBUG] Size or service offering changed for %s, starting scale", name)
+
+		// Create a new parameter struct
+		p := cs.Kubernetes.NewScaleKubernetesClusterParams(d.Id())
+
+		if d.HasChange("size") {
+			p.SetSize(int64(d.Get("size").(int)))
+		}
+
+		if d.HasChange("service_offering") {
+			// Retrieve the service_offering ID
+			serviceofferingid, e := retrieveID(cs, "service_offering", d.Get("service_offering").(string))
+			if e != nil {
+				return e.Error()
+			}
+			p.SetServiceofferingid(serviceofferingid)
+		}
+
+		// Scale the cluster
+		_, err := cs.Kubernetes.ScaleKubernetesCluster(p)
+		if err != nil {
+			return fmt.Errorf(
+				"Error scaling Kubernetes cluster %s: %s", name, err)
+		}
+	}
+
+	// Stop the cluster as the very last step
+	if d.HasChange("state") && state == "Stopped" {
+		if err := resourceCloudStackKubernetesClusterStop(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackKubernetesClusterRead(d, meta)
+}
+
+func resourceCloudStackKubernetesClusterDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewDeleteKubernetesClusterParams(d.Id())
+
+	// Delete the Kubernetes cluster
+	log.Printf("[INFO] Deleting Kubernetes cluster: %s", d.Get("name").(string))
+	_, err := cs.Kubernetes.DeleteKubernetesCluster(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Kubernetes cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func resourceCloudStackKubernetesClusterStart(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	log.Printf("[DEBUG] Starting Kubernetes cluster %s", d.Get("name").(string))
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewStartKubernetesClusterParams(d.Id())
+
+	// Start the cluster
+	if _, err := cs.Kubernetes.StartKubernetesCluster(p); err != nil {
+		return fmt.Errorf(
+			"Error starting Kubernetes cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func resourceCloudStackKubernetesClusterStop(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	log.Printf("[DEBUG] Stopping Kubernetes cluster %s", d.Get("name").(string))
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewStopKubernetesClusterParams(d.Id())
+
+	// Stop the cluster
+	if _, err := cs.Kubernetes.StopKubernetesCluster(p); err != nil {
+		return fmt.Errorf(
+			"Error stopping Kubernetes cluster %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func verifyKubernetesClusterParams(d *schema.ResourceData) error {
+	// Only verify a configured state, as the cluster itself can be in many more
+	if d.HasChange("state") {
+		state := d.Get("state").(string)
+
+		switch state {
+		case "Running", "Stopped":
+			// These are supported
+		default:
+			return fmt.Errorf(
+				"%q is not a valid state. Valid options are 'Running' and 'Stopped'", state)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackKubernetesCluster_basic(t *testing.T) {
+	if CLOUDSTACK_KUBERNETES_ISO_URL == "" {
+		t.Skip("This test requires a Kubernetes ISO URL")
+	}
+
+	var cluster cloudstack.KubernetesCluster
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackKubernetesCluster_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesClusterExists(
+						"cloudstack_kubernetes_cluster.foo", &cluster),
+					testAccCheckCloudStackKubernetesClusterAttributes(&cluster),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_cluster.foo", "state", "Running"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_kubernetes_cluster.foo", "kube_config"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackKubernetesCluster_update(t *testing.T) {
+	if CLOUDSTACK_KUBERNETES_ISO_URL == "" {
+		t.Skip("This test requires a Kubernetes ISO URL")
+	}
+
+	var cluster cloudstack.KubernetesCluster
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackKubernetesCluster_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesClusterExists(
+						"cloudstack_kubernetes_cluster.foo", &cluster),
+					testAccCheckCloudStackKubernetesClusterAttributes(&cluster),
+				),
+			},
+
+			{
+				Config: testAccCloudStackKubernetesCluster_scale,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesClusterExists(
+						"cloudstack_kubernetes_cluster.foo", &cluster),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_cluster.foo", "size", "2"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackKubernetesCluster_stopped,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesClusterExists(
+						"cloudstack_kubernetes_cluster.foo", &cluster),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_cluster.foo", "state", "Stopped"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackKubernetesClusterExists(
+	n string, cluster *cloudstack.KubernetesCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kubernetes cluster ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		c, _, err := cs.Kubernetes.GetKubernetesClusterByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if c.Id != rs.Primary.ID {
+			return fmt.Errorf("Kubernetes cluster not found")
+		}
+
+		*cluster = *c
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackKubernetesClusterAttributes(
+	cluster *cloudstack.KubernetesCluster) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if cluster.Name != "terraform-test" {
+			return fmt.Errorf("Bad name: %s", cluster.Name)
+		}
+
+		if cluster.Size != 1 {
+			return fmt.Errorf("Bad size: %d", cluster.Size)
+		}
+
+		if cluster.Serviceofferingname != "Medium Instance" {
+			return fmt.Errorf("Bad service offering: %s", cluster.Serviceofferingname)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackKubernetesClusterDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_kubernetes_cluster" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kubernetes cluster ID is set")
+		}
+
+		_, _, err := cs.Kubernetes.GetKubernetesClusterByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Kubernetes cluster %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccCloudStackKubernetesCluster_basic = fmt.Sprintf(`
+resource "cloudstack_kubernetes_version" "foo" {
+  semantic_version = "1.24.0"
+  url = "%s"
+  min_cpu_number = 1
+  min_memory = 1024
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_kubernetes_cluster" "foo" {
+  name = "terraform-test"
+  kubernetes_version = "${cloudstack_kubernetes_version.foo.id}"
+  service_offering = "Medium Instance"
+  size = 1
+  zone = "Sandbox-simulator"
+}`, CLOUDSTACK_KUBERNETES_ISO_URL)
+
+var testAccCloudStackKubernetesCluster_scale = fmt.Sprintf(`
+resource "cloudstack_kubernetes_version" "foo" {
+  semantic_version = "1.24.0"
+  url = "%s"
+  min_cpu_number = 1
+  min_memory = 1024
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_kubernetes_cluster" "foo" {
+  name = "terraform-test"
+  kubernetes_version = "${cloudstack_kubernetes_version.foo.id}"
+  service_offering = "Medium Instance"
+  size = 2
+  zone = "Sandbox-simulator"
+}`, CLOUDSTACK_KUBERNETES_ISO_URL)
+
+var testAccCloudStackKubernetesCluster_stopped = fmt.Sprintf(`
+resource "cloudstack_kubernetes_version" "foo" {
+  semantic_version = "1.24.0"
+  url = "%s"
+  min_cpu_number = 1
+  min_memory = 1024
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_kubernetes_cluster" "foo" {
+  name = "terraform-test"
+  kubernetes_version = "${cloudstack_kubernetes_version.foo.id}"
+  service_offering = "Medium Instance"
+  size = 2
+  zone = "Sandbox-simulator"
+  state = "Stopped"
+}`, CLOUDSTACK_KUBERNETES_ISO_URL)
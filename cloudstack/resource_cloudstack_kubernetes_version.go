@@ -0,0 +1,252 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackKubernetesVersion() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackKubernetesVersionCreate,
+		Read:   resourceCloudStackKubernetesVersionRead,
+		Update: resourceCloudStackKubernetesVersionUpdate,
+		Delete: resourceCloudStackKubernetesVersionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"semantic_version": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"checksum": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"min_cpu_number": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"min_memory": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"iso_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"iso_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackKubernetesVersionCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyKubernetesVersionParams(d); err != nil {
+		return err
+	}
+
+	semanticversion := d.Get("semantic_version").(string)
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewAddKubernetesSupportedVersionParams(
+		d.Get("min_cpu_number").(int),
+		d.Get("min_memory").(int),
+		semanticversion,
+	)
+
+	p.SetUrl(d.Get("url").(string))
+
+	if name, ok := d.GetOk("name"); ok {
+		p.SetName(name.(string))
+	}
+
+	if checksum, ok := d.GetOk("checksum"); ok {
+		p.SetChecksum(checksum.(string))
+	}
+
+	// Retrieve the zone ID
+	if zone, ok := d.GetOk("zone"); ok {
+		zoneid, e := retrieveID(cs, "zone", zone.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetZoneid(zoneid)
+	}
+
+	// Add the new Kubernetes version
+	r, err := cs.Kubernetes.AddKubernetesSupportedVersion(p)
+	if err != nil {
+		return fmt.Errorf("Error adding Kubernetes version %s: %s", semanticversion, err)
+	}
+
+	d.SetId(r.Id)
+
+	// Disable the version if requested, new versions are always enabled
+	if state, ok := d.GetOk("state"); ok && state.(string) != r.State {
+		up := cs.Kubernetes.NewUpdateKubernetesSupportedVersionParams(r.Id, state.(string))
+		if _, err := cs.Kubernetes.UpdateKubernetesSupportedVersion(up); err != nil {
+			return fmt.Errorf(
+				"Error setting the state of Kubernetes version %s: %s", semanticversion, err)
+		}
+	}
+
+	return resourceCloudStackKubernetesVersionRead(d, meta)
+}
+
+func resourceCloudStackKubernetesVersionRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the Kubernetes version details
+	v, count, err := cs.Kubernetes.GetKubernetesSupportedVersionByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf(
+				"[DEBUG] Kubernetes version %s does no longer exist", d.Get("semantic_version").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("semantic_version", v.Semanticversion); err != nil {
+		return err
+	}
+	if err := d.Set("name", v.Name); err != nil {
+		return err
+	}
+	if err := d.Set("min_cpu_number", v.Mincpunumber); err != nil {
+		return err
+	}
+	if err := d.Set("min_memory", v.Minmemory); err != nil {
+		return err
+	}
+	if err := d.Set("state", v.State); err != nil {
+		return err
+	}
+	if err := d.Set("iso_id", v.Isoid); err != nil {
+		return err
+	}
+	if err := d.Set("iso_state", v.Isostate); err != nil {
+		return err
+	}
+
+	// Only set the zone if the version is not available in all zones
+	if v.Zoneid != "" {
+		setValueOrID(d, "zone", v.Zonename, v.Zoneid)
+	}
+
+	return nil
+}
+
+func resourceCloudStackKubernetesVersionUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyKubernetesVersionParams(d); err != nil {
+		return err
+	}
+
+	if d.HasChange("state") {
+		semanticversion := d.Get("semantic_version").(string)
+
+		log.Printf("[DEBUG] State changed for Kubernetes version %s, starting update", semanticversion)
+
+		// Create a new parameter struct
+		p := cs.Kubernetes.NewUpdateKubernetesSupportedVersionParams(d.Id(), d.Get("state").(string))
+
+		// Update the state
+		_, err := cs.Kubernetes.UpdateKubernetesSupportedVersion(p)
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating the state of Kubernetes version %s: %s", semanticversion, err)
+		}
+	}
+
+	return resourceCloudStackKubernetesVersionRead(d, meta)
+}
+
+func resourceCloudStackKubernetesVersionDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Kubernetes.NewDeleteKubernetesSupportedVersionParams(d.Id())
+
+	// Delete the Kubernetes version
+	log.Printf("[INFO] Deleting Kubernetes version: %s", d.Get("semantic_version").(string))
+	_, err := cs.Kubernetes.DeleteKubernetesSupportedVersion(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"Error deleting Kubernetes version %s: %s", d.Get("semantic_version").(string), err)
+	}
+
+	return nil
+}
+
+func verifyKubernetesVersionParams(d *schema.ResourceData) error {
+	if state, ok := d.GetOk("state"); ok {
+		state := state.(string)
+
+		switch state {
+		case "Enabled", "Disabled":
+			// These are supported
+		default:
+			return fmt.Errorf(
+				"%q is not a valid state. Valid options are 'Enabled' and 'Disabled'", state)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackKubernetesVersion_basic(t *testing.T) {
+	if CLOUDSTACK_KUBERNETES_ISO_URL == "" {
+		t.Skip("This test requires a Kubernetes ISO URL")
+	}
+
+	var version cloudstack.KubernetesSupportedVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackKubernetesVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackKubernetesVersion_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesVersionExists(
+						"cloudstack_kubernetes_version.foo", &version),
+					testAccCheckCloudStackKubernetesVersionAttributes(&version),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_version.foo", "state", "Enabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackKubernetesVersion_update(t *testing.T) {
+	if CLOUDSTACK_KUBERNETES_ISO_URL == "" {
+		t.Skip("This test requires a Kubernetes ISO URL")
+	}
+
+	var version cloudstack.KubernetesSupportedVersion
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackKubernetesVersionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackKubernetesVersion_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesVersionExists(
+						"cloudstack_kubernetes_version.foo", &version),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_version.foo", "state", "Enabled"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackKubernetesVersion_disabled,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackKubernetesVersionExists(
+						"cloudstack_kubernetes_version.foo", &version),
+					resource.TestCheckResourceAttr(
+						"cloudstack_kubernetes_version.foo", "state", "Disabled"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackKubernetesVersionExists(
+	n string, version *cloudstack.KubernetesSupportedVersion) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kubernetes version ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		v, _, err := cs.Kubernetes.GetKubernetesSupportedVersionByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if v.Id != rs.Primary.ID {
+			return fmt.Errorf("Kubernetes version not found")
+		}
+
+		*version = *v
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackKubernetesVersionAttributes(
+	version *cloudstack.KubernetesSupportedVersion) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if version.Semanticversion != "1.24.0" {
+			return fmt.Errorf("Bad semantic version: %s", version.Semanticversion)
+		}
+
+		if version.Mincpunumber != 2 {
+			return fmt.Errorf("Bad minimum CPU number: %d", version.Mincpunumber)
+		}
+
+		if version.Minmemory != 2048 {
+			return fmt.Errorf("Bad minimum memory: %d", version.Minmemory)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackKubernetesVersionDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_kubernetes_version" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Kubernetes version ID is set")
+		}
+
+		_, _, err := cs.Kubernetes.GetKubernetesSupportedVersionByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Kubernetes version %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+var testAccCloudStackKubernetesVersion_basic = fmt.Sprintf(`
+resource "cloudstack_kubernetes_version" "foo" {
+  semantic_version = "1.24.0"
+  name = "terraform-test"
+  url = "%s"
+  min_cpu_number = 2
+  min_memory = 2048
+  zone = "Sandbox-simulator"
+}`, CLOUDSTACK_KUBERNETES_ISO_URL)
+
+var testAccCloudStackKubernetesVersion_disabled = fmt.Sprintf(`
+resource "cloudstack_kubernetes_version" "foo" {
+  semantic_version = "1.24.0"
+  name = "terraform-test"
+  url = "%s"
+  min_cpu_number = 2
+  min_memory = 2048
+  zone = "Sandbox-simulator"
+  state = "Disabled"
+}`, CLOUDSTACK_KUBERNETES_ISO_URL)
@@ -0,0 +1,114 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackLdapConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackLdapConfigurationCreate,
+		Read:   resourceCloudStackLdapConfigurationRead,
+		Delete: resourceCloudStackLdapConfigurationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"port": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackLdapConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	hostname := d.Get("hostname").(string)
+	port := d.Get("port").(int)
+
+	// Create a new parameter struct
+	p := cs.LDAP.NewAddLdapConfigurationParams(hostname, port)
+
+	if v, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(v.(string))
+	}
+
+	// Add the LDAP configuration
+	_, err := cs.LDAP.AddLdapConfiguration(p)
+	if err != nil {
+		return fmt.Errorf("Error adding LDAP configuration %s:%d: %s", hostname, port, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", hostname, port))
+
+	return resourceCloudStackLdapConfigurationRead(d, meta)
+}
+
+func resourceCloudStackLdapConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LDAP.NewListLdapConfigurationsParams()
+	p.SetHostname(d.Get("hostname").(string))
+	p.SetPort(d.Get("port").(int))
+
+	if v, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(v.(string))
+	}
+
+	l, err := cs.LDAP.ListLdapConfigurations(p)
+	if err != nil {
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] LDAP configuration %s does no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceCloudStackLdapConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LDAP.NewDeleteLdapConfigurationParams(d.Get("hostname").(string))
+	p.SetPort(d.Get("port").(int))
+
+	if v, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(v.(string))
+	}
+
+	// Delete the LDAP configuration
+	log.Printf("[INFO] Deleting LDAP configuration: %s", d.Id())
+	_, err := cs.LDAP.DeleteLdapConfiguration(p)
+	if err != nil {
+		// This is a very poor way to be told the configuration does no longer exist :(
+		if strings.Contains(err.Error(), "Cannot find configuration with hostname") {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting LDAP configuration %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
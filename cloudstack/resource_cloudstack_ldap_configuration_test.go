@@ -0,0 +1,99 @@
+package cloudstack
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackLdapConfiguration_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackLdapConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackLdapConfiguration_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackLdapConfigurationExists(
+						"cloudstack_ldap_configuration.foo"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_ldap_configuration.foo", "id", "localhost:10389"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackLdapConfigurationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No LDAP configuration ID is set")
+		}
+
+		count, err := testAccCountLdapConfigurations(rs)
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return fmt.Errorf("LDAP configuration not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackLdapConfigurationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_ldap_configuration" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No LDAP configuration ID is set")
+		}
+
+		count, err := testAccCountLdapConfigurations(rs)
+		if err == nil && count > 0 {
+			return fmt.Errorf("LDAP configuration %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCountLdapConfigurations(rs *terraform.ResourceState) (int, error) {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	port, err := strconv.Atoi(rs.Primary.Attributes["port"])
+	if err != nil {
+		return 0, err
+	}
+
+	p := cs.LDAP.NewListLdapConfigurationsParams()
+	p.SetHostname(rs.Primary.Attributes["hostname"])
+	p.SetPort(port)
+
+	l, err := cs.LDAP.ListLdapConfigurations(p)
+	if err != nil {
+		return 0, err
+	}
+
+	return l.Count, nil
+}
+
+const testAccCloudStackLdapConfiguration_basic = `
+resource "cloudstack_ldap_configuration" "foo" {
+  hostname = "localhost"
+  port = 10389
+}`
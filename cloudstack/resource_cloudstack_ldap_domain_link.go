@@ -0,0 +1,172 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackLdapDomainLink() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackLdapDomainLinkCreate,
+		Read:   resourceCloudStackLdapDomainLinkRead,
+		Delete: resourceCloudStackLdapDomainLinkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "ldap_domain"},
+			},
+
+			"ldap_domain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"account_type": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ForceNew: true,
+			},
+
+			"admin": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"admin_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackLdapDomainLinkCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyLdapDomainLinkParams(d); err != nil {
+		return err
+	}
+
+	domainid := d.Get("domain_id").(string)
+
+	// Create a new parameter struct
+	p := cs.LDAP.NewLinkDomainToLdapParams(
+		d.Get("account_type").(int),
+		domainid,
+		d.Get("type").(string),
+	)
+
+	if v, ok := d.GetOk("name"); ok {
+		p.SetName(v.(string))
+	}
+
+	if v, ok := d.GetOk("ldap_domain"); ok {
+		p.SetLdapdomain(v.(string))
+	}
+
+	if v, ok := d.GetOk("admin"); ok {
+		p.SetAdmin(v.(string))
+	}
+
+	// Link the domain to LDAP
+	r, err := cs.LDAP.LinkDomainToLdap(p)
+	if err != nil {
+		return fmt.Errorf("Error linking domain %s to LDAP: %s", domainid, err)
+	}
+
+	d.SetId(domainid)
+
+	if err := d.Set("admin_account_id", r.Accountid); err != nil {
+		return err
+	}
+
+	return resourceCloudStackLdapDomainLinkRead(d, meta)
+}
+
+func resourceCloudStackLdapDomainLinkRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// The API doesn't expose the link itself, so check what it is made of
+	// instead, starting with the linked domain
+	_, count, err := cs.Domain.GetDomainByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Domain %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	// Check if the domain admin account created by the link still exists
+	if accountid := d.Get("admin_account_id").(string); accountid != "" {
+		p := cs.Account.NewListAccountsParams()
+		p.SetId(accountid)
+		p.SetDomainid(d.Id())
+		p.SetListall(true)
+
+		l, err := cs.Account.ListAccounts(p)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the admin account of domain %s: %s", d.Id(), err)
+		}
+
+		if l.Count == 0 {
+			log.Printf("[DEBUG] Admin account %s of domain %s does no longer exist", accountid, d.Id())
+			if err := d.Set("admin", ""); err != nil {
+				return err
+			}
+			if err := d.Set("admin_account_id", ""); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudStackLdapDomainLinkDelete(d *schema.ResourceData, meta interface{}) error {
+	// There is no API to unlink a domain, so we can only forget about the link
+	log.Printf("[WARN] Domain %s remains linked to LDAP, as CloudStack cannot unlink it", d.Id())
+	d.SetId("")
+
+	return nil
+}
+
+func verifyLdapDomainLinkParams(d *schema.ResourceData) error {
+	linkType := d.Get("type").(string)
+	if linkType != "GROUP" && linkType != "OU" {
+		return fmt.Errorf(
+			"%q is not a valid link type. Valid options are 'GROUP' and 'OU'", linkType)
+	}
+
+	accountType := d.Get("account_type").(int)
+	if accountType != 0 && accountType != 2 {
+		return fmt.Errorf(
+			"%d is not a valid account type. Valid options are 0 (user) and 2 (domain admin)",
+			accountType)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCloudStackLdapDomainLink_basic(t *testing.T) {
+	if CLOUDSTACK_DOMAIN_ID == "" {
+		t.Skip("This test requires the ID of a domain to link to LDAP")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackLdapDomainLink_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"cloudstack_ldap_domain_link.foo", "id", CLOUDSTACK_DOMAIN_ID),
+					resource.TestCheckResourceAttr(
+						"cloudstack_ldap_domain_link.foo", "type", "GROUP"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudStackLdapDomainLink_basic() string {
+	return fmt.Sprintf(`
+resource "cloudstack_ldap_configuration" "foo" {
+  hostname = "localhost"
+  port = 10389
+  domain_id = "%s"
+}
+
+resource "cloudstack_ldap_domain_link" "foo" {
+  domain_id = cloudstack_ldap_configuration.foo.domain_id
+  type = "GROUP"
+  name = "terraform"
+}`, CLOUDSTACK_DOMAIN_ID)
+}
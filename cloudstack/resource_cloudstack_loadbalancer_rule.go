@@ -79,6 +79,81 @@ func resourceCloudStackLoadBalancerRule() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"stickiness_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"method": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"params": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"health_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ping_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+
+						"interval": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"response_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"healthy_threshold": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"unhealthy_threshold": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			"project": {
 				Type:       schema.TypeString,
 				ConfigMode: schema.SchemaConfigModeAttr,
@@ -161,6 +236,18 @@ func resourceCloudStackLoadBalancerRuleCreate(d *schema.ResourceData, meta inter
 		return err
 	}
 
+	if policies := d.Get("stickiness_policy").([]interface{}); len(policies) > 0 {
+		if err := createLoadBalancerStickinessPolicy(d, meta, policies[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if checks := d.Get("health_check").([]interface{}); len(checks) > 0 {
+		if err := createLoadBalancerHealthCheckPolicy(d, meta, checks[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
 	return resourceCloudStackLoadBalancerRuleRead(d, meta)
 }
 
@@ -210,6 +297,43 @@ func resourceCloudStackLoadBalancerRuleRead(d *schema.ResourceData, meta interfa
 
 	setValueOrID(d, "project", lb.Project, lb.Projectid)
 
+	// Only read the certificate if one is configured, as only SSL rules can have one
+	if _, ok := d.GetOk("certificate_id"); ok {
+		cp := cs.LoadBalancer.NewListSslCertsParams()
+		cp.SetLbruleid(d.Id())
+
+		// If there is a project supplied, we retrieve and set the project id
+		if err := setProjectid(cp, cs, d); err != nil {
+			return err
+		}
+
+		c, err := cs.LoadBalancer.ListSslCerts(cp)
+		if err != nil {
+			return err
+		}
+
+		certificateID := ""
+		if c.Count > 0 {
+			certificateID = c.SslCerts[0].Id
+		}
+		if err := d.Set("certificate_id", certificateID); err != nil {
+			return err
+		}
+	}
+
+	// Only read the policies if they are configured to avoid spurious diffs
+	if _, ok := d.GetOk("stickiness_policy"); ok {
+		if err := readLoadBalancerStickinessPolicy(d, meta); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := d.GetOk("health_check"); ok {
+		if err := readLoadBalancerHealthCheckPolicy(d, meta); err != nil {
+			return err
+		}
+	}
+
 	p := cs.LoadBalancer.NewListLoadBalancerRuleInstancesParams(d.Id())
 	l, err := cs.LoadBalancer.ListLoadBalancerRuleInstances(p)
 	if err != nil {
@@ -275,15 +399,26 @@ func resourceCloudStackLoadBalancerRuleUpdate(d *schema.ResourceData, meta inter
 	}
 
 	if d.HasChange("certificate_id") {
-		p := cs.LoadBalancer.NewRemoveCertFromLoadBalancerParams(d.Id())
-		if _, err := cs.LoadBalancer.RemoveCertFromLoadBalancer(p); err != nil {
-			return err
+		o, n := d.GetChange("certificate_id")
+
+		log.Printf(
+			"[DEBUG] Certificate has changed for load balancer rule %s, starting update",
+			d.Get("name").(string),
+		)
+
+		// Only one certificate can be bound, so first remove the current one
+		if o.(string) != "" {
+			p := cs.LoadBalancer.NewRemoveCertFromLoadBalancerParams(d.Id())
+			if _, err := cs.LoadBalancer.RemoveCertFromLoadBalancer(p); err != nil {
+				return err
+			}
 		}
 
-		_, certificateID := d.GetChange("certificate_id")
-		cp := cs.LoadBalancer.NewAssignCertToLoadBalancerParams(certificateID.(string), d.Id())
-		if _, err := cs.LoadBalancer.AssignCertToLoadBalancer(cp); err != nil {
-			return err
+		if n.(string) != "" {
+			cp := cs.LoadBalancer.NewAssignCertToLoadBalancerParams(n.(string), d.Id())
+			if _, err := cs.LoadBalancer.AssignCertToLoadBalancer(cp); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -321,6 +456,45 @@ func resourceCloudStackLoadBalancerRuleUpdate(d *schema.ResourceData, meta inter
 		}
 	}
 
+	// Policies cannot be updated, so replace them when they have changed
+	if d.HasChange("stickiness_policy") {
+		o, n := d.GetChange("stickiness_policy")
+
+		if policies := o.([]interface{}); len(policies) > 0 {
+			policy := policies[0].(map[string]interface{})
+			p := cs.LoadBalancer.NewDeleteLBStickinessPolicyParams(policy["id"].(string))
+			if _, err := cs.LoadBalancer.DeleteLBStickinessPolicy(p); err != nil {
+				return fmt.Errorf(
+					"Error deleting stickiness policy of load balancer rule %s: %s", d.Get("name").(string), err)
+			}
+		}
+
+		if policies := n.([]interface{}); len(policies) > 0 {
+			if err := createLoadBalancerStickinessPolicy(d, meta, policies[0].(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("health_check") {
+		o, n := d.GetChange("health_check")
+
+		if checks := o.([]interface{}); len(checks) > 0 {
+			check := checks[0].(map[string]interface{})
+			p := cs.LoadBalancer.NewDeleteLBHealthCheckPolicyParams(check["id"].(string))
+			if _, err := cs.LoadBalancer.DeleteLBHealthCheckPolicy(p); err != nil {
+				return fmt.Errorf(
+					"Error deleting health check of load balancer rule %s: %s", d.Get("name").(string), err)
+			}
+		}
+
+		if checks := n.([]interface{}); len(checks) > 0 {
+			if err := createLoadBalancerHealthCheckPolicy(d, meta, checks[0].(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
 	return resourceCloudStackLoadBalancerRuleRead(d, meta)
 }
 
@@ -343,16 +517,161 @@ func resourceCloudStackLoadBalancerRuleDelete(d *schema.ResourceData, meta inter
 	return nil
 }
 
+func createLoadBalancerStickinessPolicy(
+	d *schema.ResourceData, meta interface{}, policy map[string]interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Default the policy name to the name of the rule
+	name := policy["name"].(string)
+	if name == "" {
+		name = d.Get("name").(string)
+	}
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewCreateLBStickinessPolicyParams(
+		d.Id(), policy["method"].(string), name)
+
+	if params := policy["params"].(map[string]interface{}); len(params) > 0 {
+		p.SetParam(tagsFromSchema(params))
+	}
+
+	log.Printf("[DEBUG] Creating stickiness policy for load balancer rule %s", d.Get("name").(string))
+	if _, err := cs.LoadBalancer.CreateLBStickinessPolicy(p); err != nil {
+		return fmt.Errorf(
+			"Error creating stickiness policy for load balancer rule %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func readLoadBalancerStickinessPolicy(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewListLBStickinessPoliciesParams()
+	p.SetLbruleid(d.Id())
+
+	l, err := cs.LoadBalancer.ListLBStickinessPolicies(p)
+	if err != nil {
+		return err
+	}
+
+	var policies []interface{}
+	if l.Count > 0 && len(l.LBStickinessPolicies[0].Stickinesspolicy) > 0 {
+		sp := l.LBStickinessPolicies[0].Stickinesspolicy[0]
+
+		// Only return the params that were explicitly set
+		var configured map[string]interface{}
+		if old := d.Get("stickiness_policy").([]interface{}); len(old) > 0 && old[0] != nil {
+			configured = old[0].(map[string]interface{})["params"].(map[string]interface{})
+		}
+
+		params := make(map[string]interface{})
+		for k, v := range sp.Params {
+			if _, ok := configured[k]; ok {
+				params[k] = v
+			}
+		}
+
+		policies = append(policies, map[string]interface{}{
+			"id":     sp.Id,
+			"name":   sp.Name,
+			"method": sp.Methodname,
+			"params": params,
+		})
+	}
+
+	return d.Set("stickiness_policy", policies)
+}
+
+func createLoadBalancerHealthCheckPolicy(
+	d *schema.ResourceData, meta interface{}, check map[string]interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewCreateLBHealthCheckPolicyParams(d.Id())
+
+	if v := check["ping_path"].(string); v != "" {
+		p.SetPingpath(v)
+	}
+
+	if v := check["interval"].(int); v > 0 {
+		p.SetIntervaltime(v)
+	}
+
+	if v := check["response_timeout"].(int); v > 0 {
+		p.SetResponsetimeout(v)
+	}
+
+	if v := check["healthy_threshold"].(int); v > 0 {
+		p.SetHealthythreshold(v)
+	}
+
+	if v := check["unhealthy_threshold"].(int); v > 0 {
+		p.SetUnhealthythreshold(v)
+	}
+
+	log.Printf("[DEBUG] Creating health check for load balancer rule %s", d.Get("name").(string))
+	if _, err := cs.LoadBalancer.CreateLBHealthCheckPolicy(p); err != nil {
+		return fmt.Errorf(
+			"Error creating health check for load balancer rule %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func readLoadBalancerHealthCheckPolicy(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewListLBHealthCheckPoliciesParams()
+	p.SetLbruleid(d.Id())
+
+	l, err := cs.LoadBalancer.ListLBHealthCheckPolicies(p)
+	if err != nil {
+		return err
+	}
+
+	var checks []interface{}
+	if l.Count > 0 && len(l.LBHealthCheckPolicies[0].Healthcheckpolicy) > 0 {
+		hc := l.LBHealthCheckPolicies[0].Healthcheckpolicy[0]
+
+		checks = append(checks, map[string]interface{}{
+			"id":                  hc.Id,
+			"ping_path":           hc.Pingpath,
+			"interval":            hc.Healthcheckinterval,
+			"response_timeout":    hc.Responsetime,
+			"healthy_threshold":   hc.Healthcheckthresshold,
+			"unhealthy_threshold": hc.Unhealthcheckthresshold,
+		})
+	}
+
+	return d.Set("health_check", checks)
+}
+
 func verifyLoadBalancerRule(d *schema.ResourceData) error {
 	if protocol, ok := d.GetOk("protocol"); ok {
 		protocol := protocol.(string)
 
 		switch protocol {
-		case "tcp", "udp", "tcp-proxy":
+		case "tcp", "udp", "tcp-proxy", "ssl":
+			// These are supported
+		default:
+			return fmt.Errorf(
+				"%q is not a valid protocol. Valid options are 'tcp', 'udp', 'tcp-proxy' or 'ssl'", protocol)
+		}
+	}
+
+	if policies := d.Get("stickiness_policy").([]interface{}); len(policies) > 0 {
+		method := policies[0].(map[string]interface{})["method"].(string)
+
+		switch method {
+		case "LbCookie", "AppCookie", "SourceBased":
 			// These are supported
 		default:
 			return fmt.Errorf(
-				"%q is not a valid protocol. Valid options are 'tcp', 'udp' of 'tcp-proxy'", protocol)
+				"%q is not a valid stickiness method. Valid options are 'LbCookie', "+
+					"'AppCookie' or 'SourceBased'", method)
 		}
 	}
 
@@ -116,6 +116,79 @@ func TestAccCloudStackLoadBalancerRule_forceNew(t *testing.T) {
 	})
 }
 
+func TestAccCloudStackLoadBalancerRule_certificate(t *testing.T) {
+	var id string
+
+	fooCert, fooKey := testAccGenerateSSLCertificate(t, "foo.example.com")
+	barCert, barKey := testAccGenerateSSLCertificate(t, "bar.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackLoadBalancerRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackLoadBalancerRule_certificate(
+					fooCert, fooKey, barCert, barKey, "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackLoadBalancerRuleExist("cloudstack_loadbalancer_rule.foo", &id),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "protocol", "ssl"),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_loadbalancer_rule.foo", "certificate_id",
+						"cloudstack_ssl_certificate.foo", "id"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackLoadBalancerRule_certificate(
+					fooCert, fooKey, barCert, barKey, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackLoadBalancerRuleExist("cloudstack_loadbalancer_rule.foo", &id),
+					resource.TestCheckResourceAttrPair(
+						"cloudstack_loadbalancer_rule.foo", "certificate_id",
+						"cloudstack_ssl_certificate.bar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackLoadBalancerRule_policies(t *testing.T) {
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackLoadBalancerRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackLoadBalancerRule_policies,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackLoadBalancerRuleExist("cloudstack_loadbalancer_rule.foo", &id),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "stickiness_policy.0.method", "LbCookie"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "stickiness_policy.0.params.cookie-name", "terraform"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "health_check.0.ping_path", "/health"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackLoadBalancerRule_policies_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackLoadBalancerRuleExist("cloudstack_loadbalancer_rule.foo", &id),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "stickiness_policy.0.method", "SourceBased"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_loadbalancer_rule.foo", "health_check.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCloudStackLoadBalancerRule_vpc(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -337,6 +410,86 @@ resource "cloudstack_loadbalancer_rule" "foo" {
   member_ids = ["${cloudstack_instance.foobar1.id}"]
 }`
 
+const testAccCloudStackLoadBalancerRule_policies = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  source_nat_ip = true
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_ipaddress" "foo" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_loadbalancer_rule" "foo" {
+  name = "terraform-lb"
+  ip_address_id = "${cloudstack_ipaddress.foo.id}"
+  algorithm = "roundrobin"
+  public_port = 80
+  private_port = 80
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+
+  stickiness_policy {
+    method = "LbCookie"
+    params = {
+      cookie-name = "terraform"
+    }
+  }
+
+  health_check {
+    ping_path = "/health"
+    interval = 10
+  }
+}`
+
+const testAccCloudStackLoadBalancerRule_policies_update = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  source_nat_ip = true
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_ipaddress" "foo" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_loadbalancer_rule" "foo" {
+  name = "terraform-lb"
+  ip_address_id = "${cloudstack_ipaddress.foo.id}"
+  algorithm = "roundrobin"
+  public_port = 80
+  private_port = 80
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+
+  stickiness_policy {
+    method = "SourceBased"
+  }
+}`
+
 const testAccCloudStackLoadBalancerRule_vpc = `
 resource "cloudstack_vpc" "foo" {
   name = "terraform-vpc"
@@ -428,3 +581,56 @@ resource "cloudstack_loadbalancer_rule" "foo" {
   private_port = 443
   member_ids = ["${cloudstack_instance.foobar1.id}", "${cloudstack_instance.foobar2.id}"]
 }`
+
+func testAccCloudStackLoadBalancerRule_certificate(
+	fooCert, fooKey, barCert, barKey, certificate string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  source_nat_ip = true
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_ipaddress" "foo" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_instance" "foobar1" {
+  name = "terraform-server1"
+  display_name = "terraform"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "${cloudstack_network.foo.zone}"
+  expunge = true
+}
+
+resource "cloudstack_ssl_certificate" "foo" {
+  name = "terraform-foo"
+  certificate = <<EOT
+%sEOT
+  private_key = <<EOT
+%sEOT
+}
+
+resource "cloudstack_ssl_certificate" "bar" {
+  name = "terraform-bar"
+  certificate = <<EOT
+%sEOT
+  private_key = <<EOT
+%sEOT
+}
+
+resource "cloudstack_loadbalancer_rule" "foo" {
+  name = "terraform-lb"
+  ip_address_id = "${cloudstack_ipaddress.foo.id}"
+  algorithm = "roundrobin"
+  public_port = 443
+  private_port = 80
+  protocol = "ssl"
+  certificate_id = "${cloudstack_ssl_certificate.%s.id}"
+  member_ids = ["${cloudstack_instance.foobar1.id}"]
+}`, fooCert, fooKey, barCert, barKey, certificate)
+}
@@ -0,0 +1,639 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackPhysicalNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackPhysicalNetworkCreate,
+		Read:   resourceCloudStackPhysicalNetworkRead,
+		Update: resourceCloudStackPhysicalNetworkUpdate,
+		Delete: resourceCloudStackPhysicalNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"isolation_methods": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"broadcast_domain_range": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"network_speed": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"vlan": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"network_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"traffic_type": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"kvm_network_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"vmware_network_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"xen_network_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"hyperv_network_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"ovm3_network_label": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"network_service_providers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceCloudStackPhysicalNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyPhysicalNetworkParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.Network.NewCreatePhysicalNetworkParams(name, zoneid)
+
+	if v, ok := d.GetOk("isolation_methods"); ok {
+		var methods []string
+		for _, m := range v.([]interface{}) {
+			methods = append(methods, m.(string))
+		}
+		p.SetIsolationmethods(methods)
+	}
+
+	if v, ok := d.GetOk("broadcast_domain_range"); ok {
+		p.SetBroadcastdomainrange(v.(string))
+	}
+
+	if v, ok := d.GetOk("network_speed"); ok {
+		p.SetNetworkspeed(v.(string))
+	}
+
+	if v, ok := d.GetOk("vlan"); ok {
+		p.SetVlan(v.(string))
+	}
+
+	if v, ok := d.GetOk("network_tags"); ok {
+		var tags []string
+		for _, t := range v.(*schema.Set).List() {
+			tags = append(tags, t.(string))
+		}
+		p.SetTags(tags)
+	}
+
+	// Create the new physical network
+	r, err := cs.Network.CreatePhysicalNetwork(p)
+	if err != nil {
+		return fmt.Errorf("Error creating physical network %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// Add the traffic types carried by the physical network
+	if err := updatePhysicalNetworkTrafficTypes(d, meta, nil, d.Get("traffic_type").([]interface{})); err != nil {
+		return err
+	}
+
+	// Enable the physical network before enabling any providers
+	if state, ok := d.GetOk("state"); ok {
+		up := cs.Network.NewUpdatePhysicalNetworkParams(d.Id())
+		up.SetState(state.(string))
+
+		if _, err := cs.Network.UpdatePhysicalNetwork(up); err != nil {
+			return fmt.Errorf("Error setting the state of physical network %s: %s", name, err)
+		}
+	}
+
+	if _, ok := d.GetOk("network_service_providers"); ok {
+		if err := updatePhysicalNetworkProviders(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackPhysicalNetworkRead(d, meta)
+}
+
+func resourceCloudStackPhysicalNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the physical network details
+	n, count, err := cs.Network.GetPhysicalNetworkByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Physical network %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", n.Name); err != nil {
+		return err
+	}
+	if err := d.Set("broadcast_domain_range", n.Broadcastdomainrange); err != nil {
+		return err
+	}
+	if err := d.Set("network_speed", n.Networkspeed); err != nil {
+		return err
+	}
+	if err := d.Set("vlan", n.Vlan); err != nil {
+		return err
+	}
+	if err := d.Set("state", n.State); err != nil {
+		return err
+	}
+
+	var methods []string
+	if n.Isolationmethods != "" {
+		methods = strings.Split(n.Isolationmethods, ",")
+	}
+	if err := d.Set("isolation_methods", methods); err != nil {
+		return err
+	}
+
+	var tags []interface{}
+	if n.Tags != "" {
+		for _, t := range strings.Split(n.Tags, ",") {
+			tags = append(tags, t)
+		}
+	}
+	if err := d.Set("network_tags", schema.NewSet(schema.HashString, tags)); err != nil {
+		return err
+	}
+
+	// The API client does not return the type or labels of a traffic type,
+	// so we only drop the configured traffic types that no longer exist
+	tp := cs.Usage.NewListTrafficTypesParams(d.Id())
+
+	l, err := cs.Usage.ListTrafficTypes(tp)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]bool)
+	for _, t := range l.TrafficTypes {
+		ids[t.Id] = true
+	}
+
+	var trafficTypes []interface{}
+	for _, t := range d.Get("traffic_type").([]interface{}) {
+		if ids[t.(map[string]interface{})["id"].(string)] {
+			trafficTypes = append(trafficTypes, t)
+		}
+	}
+	if err := d.Set("traffic_type", trafficTypes); err != nil {
+		return err
+	}
+
+	// Get the enabled network service providers
+	pp := cs.Network.NewListNetworkServiceProvidersParams()
+	pp.SetPhysicalnetworkid(d.Id())
+	pp.SetState("Enabled")
+
+	providers, err := cs.Network.ListNetworkServiceProviders(pp)
+	if err != nil {
+		return err
+	}
+
+	var names []interface{}
+	for _, nsp := range providers.NetworkServiceProviders {
+		names = append(names, nsp.Name)
+	}
+	if err := d.Set("network_service_providers", schema.NewSet(schema.HashString, names)); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "zone", n.Zonename, n.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackPhysicalNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyPhysicalNetworkParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	if d.HasChange("network_speed") || d.HasChange("vlan") ||
+		d.HasChange("network_tags") || d.HasChange("state") {
+		// Create a new parameter struct
+		p := cs.Network.NewUpdatePhysicalNetworkParams(d.Id())
+
+		if d.HasChange("network_speed") {
+			p.SetNetworkspeed(d.Get("network_speed").(string))
+		}
+
+		if d.HasChange("vlan") {
+			p.SetVlan(d.Get("vlan").(string))
+		}
+
+		if d.HasChange("network_tags") {
+			var tags []string
+			for _, t := range d.Get("network_tags").(*schema.Set).List() {
+				tags = append(tags, t.(string))
+			}
+			p.SetTags(tags)
+		}
+
+		if d.HasChange("state") {
+			p.SetState(d.Get("state").(string))
+		}
+
+		log.Printf("[DEBUG] Updating physical network %s", name)
+		if _, err := cs.Network.UpdatePhysicalNetwork(p); err != nil {
+			return fmt.Errorf("Error updating physical network %s: %s", name, err)
+		}
+	}
+
+	if d.HasChange("traffic_type") {
+		o, n := d.GetChange("traffic_type")
+		if err := updatePhysicalNetworkTrafficTypes(d, meta, o.([]interface{}), n.([]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("network_service_providers") {
+		if err := updatePhysicalNetworkProviders(d, meta); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackPhysicalNetworkRead(d, meta)
+}
+
+func resourceCloudStackPhysicalNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Network.NewDeletePhysicalNetworkParams(d.Id())
+
+	// Delete the physical network
+	log.Printf("[INFO] Deleting physical network: %s", d.Get("name").(string))
+	_, err := cs.Network.DeletePhysicalNetwork(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting physical network %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func updatePhysicalNetworkTrafficTypes(
+	d *schema.ResourceData, meta interface{}, o []interface{}, n []interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	current := make(map[string]map[string]interface{})
+	for _, t := range o {
+		t := t.(map[string]interface{})
+		current[t["type"].(string)] = t
+	}
+
+	wanted := make(map[string]bool)
+	for _, t := range n {
+		wanted[t.(map[string]interface{})["type"].(string)] = true
+	}
+
+	// Delete the traffic types that are no longer configured
+	for trafficType, t := range current {
+		if wanted[trafficType] {
+			continue
+		}
+
+		p := cs.Usage.NewDeleteTrafficTypeParams(t["id"].(string))
+
+		log.Printf("[DEBUG] Deleting traffic type %s from physical network %s", trafficType, d.Id())
+		if _, err := cs.Usage.DeleteTrafficType(p); err != nil {
+			return fmt.Errorf("Error deleting traffic type %s: %s", trafficType, err)
+		}
+	}
+
+	var trafficTypes []interface{}
+	for _, t := range n {
+		t := t.(map[string]interface{})
+		trafficType := t["type"].(string)
+
+		if c, ok := current[trafficType]; ok {
+			// Update the labels of an existing traffic type
+			t["id"] = c["id"]
+
+			p := cs.Usage.NewUpdateTrafficTypeParams(c["id"].(string))
+			p.SetKvmnetworklabel(t["kvm_network_label"].(string))
+			p.SetVmwarenetworklabel(t["vmware_network_label"].(string))
+			p.SetXennetworklabel(t["xen_network_label"].(string))
+			p.SetHypervnetworklabel(t["hyperv_network_label"].(string))
+			p.SetOvm3networklabel(t["ovm3_network_label"].(string))
+
+			log.Printf("[DEBUG] Updating traffic type %s of physical network %s", trafficType, d.Id())
+			if _, err := cs.Usage.UpdateTrafficType(p); err != nil {
+				return fmt.Errorf("Error updating traffic type %s: %s", trafficType, err)
+			}
+		} else {
+			p := cs.Usage.NewAddTrafficTypeParams(d.Id(), trafficType)
+
+			if v := t["kvm_network_label"].(string); v != "" {
+				p.SetKvmnetworklabel(v)
+			}
+			if v := t["vmware_network_label"].(string); v != "" {
+				p.SetVmwarenetworklabel(v)
+			}
+			if v := t["xen_network_label"].(string); v != "" {
+				p.SetXennetworklabel(v)
+			}
+			if v := t["hyperv_network_label"].(string); v != "" {
+				p.SetHypervnetworklabel(v)
+			}
+			if v := t["ovm3_network_label"].(string); v != "" {
+				p.SetOvm3networklabel(v)
+			}
+
+			log.Printf("[DEBUG] Adding traffic type %s to physical network %s", trafficType, d.Id())
+			r, err := cs.Usage.AddTrafficType(p)
+			if err != nil {
+				return fmt.Errorf("Error adding traffic type %s: %s", trafficType, err)
+			}
+
+			t["id"] = r.Id
+		}
+
+		trafficTypes = append(trafficTypes, t)
+	}
+
+	return d.Set("traffic_type", trafficTypes)
+}
+
+func updatePhysicalNetworkProviders(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get all network service providers of the physical network
+	p := cs.Network.NewListNetworkServiceProvidersParams()
+	p.SetPhysicalnetworkid(d.Id())
+
+	l, err := cs.Network.ListNetworkServiceProviders(p)
+	if err != nil {
+		return err
+	}
+
+	providers := make(map[string]*cloudstack.NetworkServiceProvider)
+	for _, nsp := range l.NetworkServiceProviders {
+		providers[nsp.Name] = nsp
+	}
+
+	wanted := d.Get("network_service_providers").(*schema.Set)
+
+	for _, name := range wanted.List() {
+		name := name.(string)
+
+		nsp, ok := providers[name]
+		if !ok {
+			ap := cs.Network.NewAddNetworkServiceProviderParams(name, d.Id())
+
+			log.Printf("[DEBUG] Adding network service provider %s to physical network %s", name, d.Id())
+			r, err := cs.Network.AddNetworkServiceProvider(ap)
+			if err != nil {
+				return fmt.Errorf("Error adding network service provider %s: %s", name, err)
+			}
+
+			nsp = &cloudstack.NetworkServiceProvider{Id: r.Id, Name: name}
+		}
+
+		if nsp.State == "Enabled" {
+			continue
+		}
+
+		// Some providers can only be enabled once their element is enabled
+		if err := enableNetworkServiceProviderElement(cs, nsp); err != nil {
+			return err
+		}
+
+		if err := setNetworkServiceProviderState(cs, nsp, "Enabled"); err != nil {
+			return err
+		}
+	}
+
+	// Disable the enabled providers that are no longer configured
+	for name, nsp := range providers {
+		if nsp.State == "Enabled" && !wanted.Contains(name) {
+			if err := setNetworkServiceProviderState(cs, nsp, "Disabled"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func enableNetworkServiceProviderElement(
+	cs *cloudstack.CloudStackClient, nsp *cloudstack.NetworkServiceProvider) error {
+	switch nsp.Name {
+	case "VirtualRouter", "VpcVirtualRouter":
+		p := cs.Router.NewListVirtualRouterElementsParams()
+		p.SetNspid(nsp.Id)
+
+		l, err := cs.Router.ListVirtualRouterElements(p)
+		if err != nil {
+			return err
+		}
+
+		var id string
+		if l.Count > 0 {
+			if l.VirtualRouterElements[0].Enabled {
+				return nil
+			}
+			id = l.VirtualRouterElements[0].Id
+		} else {
+			r, err := cs.Router.CreateVirtualRouterElement(
+				cs.Router.NewCreateVirtualRouterElementParams(nsp.Id))
+			if err != nil {
+				return fmt.Errorf("Error creating the element of provider %s: %s", nsp.Name, err)
+			}
+			id = r.Id
+		}
+
+		log.Printf("[DEBUG] Enabling the element of network service provider %s", nsp.Name)
+		if _, err := cs.Router.ConfigureVirtualRouterElement(
+			cs.Router.NewConfigureVirtualRouterElementParams(true, id)); err != nil {
+			return fmt.Errorf("Error enabling the element of provider %s: %s", nsp.Name, err)
+		}
+
+	case "InternalLbVm":
+		p := cs.InternalLB.NewListInternalLoadBalancerElementsParams()
+		p.SetNspid(nsp.Id)
+
+		l, err := cs.InternalLB.ListInternalLoadBalancerElements(p)
+		if err != nil {
+			return err
+		}
+
+		var id string
+		if l.Count > 0 {
+			if l.InternalLoadBalancerElements[0].Enabled {
+				return nil
+			}
+			id = l.InternalLoadBalancerElements[0].Id
+		} else {
+			r, err := cs.InternalLB.CreateInternalLoadBalancerElement(
+				cs.InternalLB.NewCreateInternalLoadBalancerElementParams(nsp.Id))
+			if err != nil {
+				return fmt.Errorf("Error creating the element of provider %s: %s", nsp.Name, err)
+			}
+			id = r.Id
+		}
+
+		log.Printf("[DEBUG] Enabling the element of network service provider %s", nsp.Name)
+		if _, err := cs.InternalLB.ConfigureInternalLoadBalancerElement(
+			cs.InternalLB.NewConfigureInternalLoadBalancerElementParams(true, id)); err != nil {
+			return fmt.Errorf("Error enabling the element of provider %s: %s", nsp.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setNetworkServiceProviderState(
+	cs *cloudstack.CloudStackClient, nsp *cloudstack.NetworkServiceProvider, state string) error {
+	p := cs.Network.NewUpdateNetworkServiceProviderParams(nsp.Id)
+	p.SetState(state)
+
+	log.Printf("[DEBUG] Setting the state of network service provider %s to %s", nsp.Name, state)
+	if _, err := cs.Network.UpdateNetworkServiceProvider(p); err != nil {
+		return fmt.Errorf(
+			"Error setting the state of network service provider %s to %s: %s", nsp.Name, state, err)
+	}
+
+	return nil
+}
+
+func verifyPhysicalNetworkParams(d *schema.ResourceData) error {
+	if state, ok := d.GetOk("state"); ok {
+		if state.(string) != "Enabled" && state.(string) != "Disabled" {
+			return fmt.Errorf(
+				"%q is not a valid state. Valid options are 'Enabled' and 'Disabled'", state.(string))
+		}
+	}
+
+	trafficTypes := make(map[string]bool)
+	for _, t := range d.Get("traffic_type").([]interface{}) {
+		trafficType := t.(map[string]interface{})["type"].(string)
+
+		switch trafficType {
+		case "Guest", "Management", "Public", "Storage":
+			// These are supported
+		default:
+			return fmt.Errorf(
+				"%q is not a valid traffic type. Valid options are 'Guest', 'Management', "+
+					"'Public' and 'Storage'", trafficType)
+		}
+
+		if trafficTypes[trafficType] {
+			return fmt.Errorf("Traffic type %s is configured more than once", trafficType)
+		}
+		trafficTypes[trafficType] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackPhysicalNetwork_basic(t *testing.T) {
+	var network cloudstack.PhysicalNetwork
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackPhysicalNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackPhysicalNetwork_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackPhysicalNetworkExists(
+						"cloudstack_physical_network.foo", &network),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "state", "Enabled"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "traffic_type.#", "2"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "network_service_providers.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackPhysicalNetwork_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackPhysicalNetworkExists(
+						"cloudstack_physical_network.foo", &network),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "vlan", "200-300"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "traffic_type.#", "3"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_physical_network.foo", "network_service_providers.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackPhysicalNetworkExists(
+	n string, network *cloudstack.PhysicalNetwork) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No physical network ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		pn, _, err := cs.Network.GetPhysicalNetworkByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if pn.Id != rs.Primary.ID {
+			return fmt.Errorf("Physical network not found")
+		}
+
+		*network = *pn
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackPhysicalNetworkDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_physical_network" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No physical network ID is set")
+		}
+
+		_, _, err := cs.Network.GetPhysicalNetworkByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Physical network %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackPhysicalNetwork_basic = `
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_physical_network" "foo" {
+  name = "terraform-physical-network"
+  zone = cloudstack_zone.foo.name
+  isolation_methods = ["VLAN"]
+  vlan = "100-200"
+  state = "Enabled"
+
+  traffic_type {
+    type = "Management"
+  }
+
+  traffic_type {
+    type = "Guest"
+  }
+
+  network_service_providers = ["VirtualRouter"]
+}`
+
+const testAccCloudStackPhysicalNetwork_update = `
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_physical_network" "foo" {
+  name = "terraform-physical-network"
+  zone = cloudstack_zone.foo.name
+  isolation_methods = ["VLAN"]
+  vlan = "200-300"
+  state = "Enabled"
+
+  traffic_type {
+    type = "Management"
+  }
+
+  traffic_type {
+    type = "Guest"
+  }
+
+  traffic_type {
+    type = "Public"
+  }
+
+  network_service_providers = ["VirtualRouter", "VpcVirtualRouter"]
+}`
@@ -0,0 +1,207 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackPod() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackPodCreate,
+		Read:   resourceCloudStackPodRead,
+		Update: resourceCloudStackPodUpdate,
+		Delete: resourceCloudStackPodDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"start_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"end_ip": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"allocation_state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackPodCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.Pod.NewCreatePodParams(
+		d.Get("gateway").(string),
+		name,
+		d.Get("netmask").(string),
+		d.Get("start_ip").(string),
+		zoneid,
+	)
+
+	if v, ok := d.GetOk("end_ip"); ok {
+		p.SetEndip(v.(string))
+	}
+
+	if v, ok := d.GetOk("allocation_state"); ok {
+		p.SetAllocationstate(v.(string))
+	}
+
+	// Create the new pod
+	r, err := cs.Pod.CreatePod(p)
+	if err != nil {
+		return fmt.Errorf("Error creating pod %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackPodRead(d, meta)
+}
+
+func resourceCloudStackPodRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the pod details
+	pod, count, err := cs.Pod.GetPodByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Pod %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", pod.Name); err != nil {
+		return err
+	}
+	if err := d.Set("gateway", pod.Gateway); err != nil {
+		return err
+	}
+	if err := d.Set("netmask", pod.Netmask); err != nil {
+		return err
+	}
+	if err := d.Set("allocation_state", pod.Allocationstate); err != nil {
+		return err
+	}
+
+	// The first range is the one the pod was created with
+	if len(pod.Startip) > 0 {
+		if err := d.Set("start_ip", pod.Startip[0]); err != nil {
+			return err
+		}
+	}
+	if len(pod.Endip) > 0 {
+		if err := d.Set("end_ip", pod.Endip[0]); err != nil {
+			return err
+		}
+	}
+
+	setValueOrID(d, "zone", pod.Zonename, pod.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackPodUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Pod.NewUpdatePodParams(d.Id())
+
+	if d.HasChange("name") {
+		p.SetName(name)
+	}
+
+	if d.HasChange("allocation_state") {
+		p.SetAllocationstate(d.Get("allocation_state").(string))
+	}
+
+	log.Printf("[DEBUG] Updating pod %s", name)
+	if _, err := cs.Pod.UpdatePod(p); err != nil {
+		return fmt.Errorf("Error updating pod %s: %s", name, err)
+	}
+
+	return resourceCloudStackPodRead(d, meta)
+}
+
+func resourceCloudStackPodDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Pod.NewDeletePodParams(d.Id())
+
+	// Delete the pod
+	log.Printf("[INFO] Deleting pod: %s", d.Get("name").(string))
+	_, err := cs.Pod.DeletePod(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting pod %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackPod_basic(t *testing.T) {
+	var pod cloudstack.Pod
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackPod_basic("terraform-pod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackPodExists("cloudstack_pod.foo", &pod),
+					resource.TestCheckResourceAttr(
+						"cloudstack_pod.foo", "start_ip", "192.168.100.10"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_pod.foo", "end_ip", "192.168.100.50"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackPod_basic("terraform-pod-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackPodExists("cloudstack_pod.foo", &pod),
+					resource.TestCheckResourceAttr(
+						"cloudstack_pod.foo", "name", "terraform-pod-updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackPod_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackPod_basic("terraform-pod"),
+			},
+
+			{
+				ResourceName:            "cloudstack_pod.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackPodExists(
+	n string, pod *cloudstack.Pod) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No pod ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		p, _, err := cs.Pod.GetPodByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if p.Id != rs.Primary.ID {
+			return fmt.Errorf("Pod not found")
+		}
+
+		*pod = *p
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackPodDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_pod" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No pod ID is set")
+		}
+
+		_, _, err := cs.Pod.GetPodByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Pod %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackPod_basic(name string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "%s"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}`, name)
+}
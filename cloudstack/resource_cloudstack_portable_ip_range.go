@@ -0,0 +1,149 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackPortableIPRange() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackPortableIPRangeCreate,
+		Read:   resourceCloudStackPortableIPRangeRead,
+		Delete: resourceCloudStackPortableIPRangeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ForceNew: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"start_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"end_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vlan": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackPortableIPRangeCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.PortableIP.NewCreatePortableIpRangeParams(
+		d.Get("end_ip").(string),
+		d.Get("gateway").(string),
+		d.Get("netmask").(string),
+		d.Get("region_id").(int),
+		d.Get("start_ip").(string),
+	)
+
+	if vlan, ok := d.GetOk("vlan"); ok {
+		p.SetVlan(vlan.(string))
+	}
+
+	// Create the new portable IP range
+	r, err := cs.PortableIP.CreatePortableIpRange(p)
+	if err != nil {
+		return fmt.Errorf("Error creating portable IP range: %s", err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackPortableIPRangeRead(d, meta)
+}
+
+func resourceCloudStackPortableIPRangeRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the portable IP range details
+	r, count, err := cs.PortableIP.GetPortableIpRangeByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Portable IP range %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("region_id", r.Regionid); err != nil {
+		return err
+	}
+	if err := d.Set("gateway", r.Gateway); err != nil {
+		return err
+	}
+	if err := d.Set("netmask", r.Netmask); err != nil {
+		return err
+	}
+	if err := d.Set("start_ip", r.Startip); err != nil {
+		return err
+	}
+	if err := d.Set("end_ip", r.Endip); err != nil {
+		return err
+	}
+	if err := d.Set("vlan", r.Vlan); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackPortableIPRangeDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.PortableIP.NewDeletePortableIpRangeParams(d.Id())
+
+	// Delete the portable IP range
+	log.Printf("[INFO] Deleting portable IP range: %s", d.Id())
+	_, err := cs.PortableIP.DeletePortableIpRange(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting portable IP range %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackPortableIPRange_basic(t *testing.T) {
+	var portable cloudstack.PortableIpRange
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackPortableIPRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackPortableIPRange_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackPortableIPRangeExists(
+						"cloudstack_portable_ip_range.foo", &portable),
+					resource.TestCheckResourceAttr(
+						"cloudstack_portable_ip_range.foo", "start_ip", "10.200.0.10"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_portable_ip_range.foo", "end_ip", "10.200.0.20"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackPortableIPRange_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackPortableIPRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackPortableIPRange_basic,
+			},
+
+			{
+				ResourceName:      "cloudstack_portable_ip_range.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackPortableIPRangeExists(
+	n string, portable *cloudstack.PortableIpRange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No portable IP range ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		r, _, err := cs.PortableIP.GetPortableIpRangeByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if r.Id != rs.Primary.ID {
+			return fmt.Errorf("Portable IP range not found")
+		}
+
+		*portable = *r
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackPortableIPRangeDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_portable_ip_range" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No portable IP range ID is set")
+		}
+
+		_, _, err := cs.PortableIP.GetPortableIpRangeByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Portable IP range %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackPortableIPRange_basic = `
+resource "cloudstack_portable_ip_range" "foo" {
+  gateway = "10.200.0.1"
+  netmask = "255.255.255.0"
+  start_ip = "10.200.0.10"
+  end_ip = "10.200.0.20"
+  vlan = "301"
+}`
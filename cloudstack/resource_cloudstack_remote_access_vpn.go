@@ -0,0 +1,165 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackRemoteAccessVPN() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackRemoteAccessVPNCreate,
+		Read:   resourceCloudStackRemoteAccessVPNRead,
+		Delete: resourceCloudStackRemoteAccessVPNDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ip_address_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ip_range": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"open_firewall": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"public_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"preshared_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackRemoteAccessVPNCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	ipaddressid := d.Get("ip_address_id").(string)
+
+	// The VPN is owned by the owner of the IP address, so there is no project
+	// to pass along. Instead make sure the IP address belongs to the project
+	// and record its project, so the VPN can be found afterwards.
+	ip, _, err := cs.Address.GetPublicIpAddressByID(
+		ipaddressid,
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		return fmt.Errorf("Error retrieving IP address ID %s: %s", ipaddressid, err)
+	}
+
+	setValueOrID(d, "project", ip.Project, ip.Projectid)
+
+	// Create a new parameter struct
+	p := cs.VPN.NewCreateRemoteAccessVpnParams(ipaddressid)
+
+	if iprange, ok := d.GetOk("ip_range"); ok {
+		p.SetIprange(iprange.(string))
+	}
+
+	p.SetOpenfirewall(d.Get("open_firewall").(bool))
+
+	// Enable remote access VPN on the IP address
+	v, err := cs.VPN.CreateRemoteAccessVpn(p)
+	if err != nil {
+		return fmt.Errorf(
+			"Error creating remote access VPN for IP address ID %s: %s", ipaddressid, err)
+	}
+
+	d.SetId(v.Id)
+
+	return resourceCloudStackRemoteAccessVPNRead(d, meta)
+}
+
+func resourceCloudStackRemoteAccessVPNRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the remote access VPN details
+	v, count, err := cs.VPN.GetRemoteAccessVpnByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf(
+				"[DEBUG] Remote access VPN for IP address ID %s does no longer exist",
+				d.Get("ip_address_id").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("ip_address_id", v.Publicipid); err != nil {
+		return err
+	}
+	if err := d.Set("ip_range", v.Iprange); err != nil {
+		return err
+	}
+	if err := d.Set("public_ip", v.Publicip); err != nil {
+		return err
+	}
+	if err := d.Set("preshared_key", v.Presharedkey); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "project", v.Project, v.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackRemoteAccessVPNDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VPN.NewDeleteRemoteAccessVpnParams(d.Get("ip_address_id").(string))
+
+	// Disable remote access VPN on the IP address
+	log.Printf("[INFO] Deleting remote access VPN: %s", d.Id())
+	_, err := cs.VPN.DeleteRemoteAccessVpn(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Get("ip_address_id").(string))) {
+			return nil
+		}
+
+		return fmt.Errorf(
+			"Error deleting remote access VPN for IP address ID %s: %s",
+			d.Get("ip_address_id").(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackRemoteAccessVPN_basic(t *testing.T) {
+	var vpn cloudstack.RemoteAccessVpn
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackRemoteAccessVPNDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackRemoteAccessVPN_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackRemoteAccessVPNExists(
+						"cloudstack_remote_access_vpn.foo", &vpn),
+					resource.TestCheckResourceAttr(
+						"cloudstack_remote_access_vpn.foo", "ip_range", "192.168.100.10-192.168.100.20"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_remote_access_vpn.foo", "preshared_key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackRemoteAccessVPNExists(
+	n string, vpn *cloudstack.RemoteAccessVpn) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No remote access VPN ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		v, _, err := cs.VPN.GetRemoteAccessVpnByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if v.Id != rs.Primary.ID {
+			return fmt.Errorf("Remote access VPN not found")
+		}
+
+		*vpn = *v
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackRemoteAccessVPNDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_remote_access_vpn" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No remote access VPN ID is set")
+		}
+
+		_, _, err := cs.VPN.GetRemoteAccessVpnByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Remote access VPN %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackRemoteAccessVPN_basic = `
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  source_nat_ip = true
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_ipaddress" "foo" {
+  network_id = "${cloudstack_network.foo.id}"
+}
+
+resource "cloudstack_remote_access_vpn" "foo" {
+  ip_address_id = "${cloudstack_ipaddress.foo.id}"
+  ip_range = "192.168.100.10-192.168.100.20"
+}`
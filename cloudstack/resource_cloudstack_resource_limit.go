@@ -0,0 +1,256 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceLimitTypes maps the supported resource types to their API values
+var resourceLimitTypes = map[string]int{
+	"instance":          0,
+	"ip":                1,
+	"volume":            2,
+	"snapshot":          3,
+	"template":          4,
+	"project":           5,
+	"network":           6,
+	"vpc":               7,
+	"cpu":               8,
+	"memory":            9,
+	"primary_storage":   10,
+	"secondary_storage": 11,
+}
+
+func resourceCloudStackResourceLimit() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackResourceLimitCreate,
+		Read:   resourceCloudStackResourceLimitRead,
+		Update: resourceCloudStackResourceLimitUpdate,
+		Delete: resourceCloudStackResourceLimitDelete,
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"max": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"account": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"domain_id"},
+				ConflictsWith: []string{"project"},
+			},
+
+			"domain_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"domain_id", "project"},
+			},
+
+			"project": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"domain_id", "project"},
+			},
+
+			"previous_max": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackResourceLimitCreate(d *schema.ResourceData, meta interface{}) error {
+	resourcetype, err := verifyResourceLimitParams(d)
+	if err != nil {
+		return err
+	}
+
+	// Remember the current limit, so it can be restored when deleting
+	limit, err := getResourceLimit(d, meta, resourcetype)
+	if err != nil {
+		return err
+	}
+
+	previous := -1
+	if limit != nil {
+		previous = int(limit.Max)
+	}
+	if err := d.Set("previous_max", previous); err != nil {
+		return err
+	}
+
+	if err := updateResourceLimit(d, meta, int64(d.Get("max").(int))); err != nil {
+		return err
+	}
+
+	// Build a unique ID from the resource type and the owner of the limit
+	owner := d.Get("project").(string)
+	if owner == "" {
+		owner = d.Get("domain_id").(string)
+		if account, ok := d.GetOk("account"); ok {
+			owner = fmt.Sprintf("%s/%s", owner, account.(string))
+		}
+	}
+	d.SetId(fmt.Sprintf("%d-%s", resourcetype, owner))
+
+	return resourceCloudStackResourceLimitRead(d, meta)
+}
+
+func resourceCloudStackResourceLimitRead(d *schema.ResourceData, meta interface{}) error {
+	resourcetype, err := verifyResourceLimitParams(d)
+	if err != nil {
+		return err
+	}
+
+	limit, err := getResourceLimit(d, meta, resourcetype)
+	if err != nil {
+		return err
+	}
+
+	if limit == nil {
+		log.Printf("[DEBUG] Resource limit %s does no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("max", int(limit.Max)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackResourceLimitUpdate(d *schema.ResourceData, meta interface{}) error {
+	if _, err := verifyResourceLimitParams(d); err != nil {
+		return err
+	}
+
+	if d.HasChange("max") {
+		if err := updateResourceLimit(d, meta, int64(d.Get("max").(int))); err != nil {
+			return err
+		}
+	}
+
+	return resourceCloudStackResourceLimitRead(d, meta)
+}
+
+func resourceCloudStackResourceLimitDelete(d *schema.ResourceData, meta interface{}) error {
+	// Resource limits cannot be deleted, so restore the previous limit instead.
+	// Limits created before the previous limit was recorded become unlimited.
+	previous := -1
+	if state := d.GetRawState(); !state.IsNull() && !state.GetAttr("previous_max").IsNull() {
+		previous = d.Get("previous_max").(int)
+	}
+	log.Printf("[INFO] Restoring the %s resource limit to %d", d.Get("type").(string), previous)
+	return updateResourceLimit(d, meta, int64(previous))
+}
+
+// getResourceLimit returns the current limit, or nil if there is none
+func getResourceLimit(d *schema.ResourceData, meta interface{}, resourcetype int) (*cloudstack.ResourceLimit, error) {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Limit.NewListResourceLimitsParams()
+	p.SetResourcetype(resourcetype)
+
+	if domainid, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(domainid.(string))
+	}
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return nil, err
+	}
+
+	// Get the current limit
+	l, err := cs.Limit.ListResourceLimits(p)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Error retrieving the %s resource limit: %s", d.Get("type").(string), err)
+	}
+
+	// Without an account only the limit of the domain itself is relevant
+	for _, rl := range l.ResourceLimits {
+		if d.Get("account").(string) == "" && d.Get("project").(string) == "" && rl.Account != "" {
+			continue
+		}
+		return rl, nil
+	}
+
+	return nil, nil
+}
+
+func updateResourceLimit(d *schema.ResourceData, meta interface{}, max int64) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	resourcetype, err := verifyResourceLimitParams(d)
+	if err != nil {
+		return err
+	}
+
+	// Create a new parameter struct
+	p := cs.Limit.NewUpdateResourceLimitParams(resourcetype)
+	p.SetMax(max)
+
+	if domainid, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(domainid.(string))
+	}
+
+	if account, ok := d.GetOk("account"); ok {
+		p.SetAccount(account.(string))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Setting the %s resource limit to %d", d.Get("type").(string), max)
+	if _, err := cs.Limit.UpdateResourceLimit(p); err != nil {
+		return fmt.Errorf(
+			"Error updating the %s resource limit: %s", d.Get("type").(string), err)
+	}
+
+	return nil
+}
+
+func verifyResourceLimitParams(d *schema.ResourceData) (int, error) {
+	t := d.Get("type").(string)
+
+	resourcetype, ok := resourceLimitTypes[t]
+	if !ok {
+		var types []string
+		for k := range resourceLimitTypes {
+			types = append(types, k)
+		}
+		sort.Strings(types)
+		return 0, fmt.Errorf(
+			"%q is not a valid resource type. Valid options are: %s", t, strings.Join(types, ", "))
+	}
+
+	if max := d.Get("max").(int); max < -1 {
+		return 0, fmt.Errorf("%d is not a valid limit, use -1 for unlimited", max)
+	}
+
+	return resourcetype, nil
+}
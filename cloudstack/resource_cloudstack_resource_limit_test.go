@@ -0,0 +1,116 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackResourceLimit_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackResourceLimitDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackResourceLimit_basic(10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackResourceLimitMax("cloudstack_resource_limit.foo", 10),
+					resource.TestCheckResourceAttr(
+						"cloudstack_resource_limit.foo", "max", "10"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_resource_limit.foo", "previous_max"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackResourceLimit_basic(20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackResourceLimitMax("cloudstack_resource_limit.foo", 20),
+					resource.TestCheckResourceAttr(
+						"cloudstack_resource_limit.foo", "max", "20"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackResourceLimitMax(n string, max int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No resource limit ID is set")
+		}
+
+		limit, err := testAccGetResourceLimit(rs)
+		if err != nil {
+			return err
+		}
+
+		if limit.Max != max {
+			return fmt.Errorf("Bad limit: expected %d, got %d", max, limit.Max)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackResourceLimitDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_resource_limit" {
+			continue
+		}
+
+		limit, err := testAccGetResourceLimit(rs)
+		if err != nil {
+			return err
+		}
+
+		if previous := rs.Primary.Attributes["previous_max"]; fmt.Sprint(limit.Max) != previous {
+			return fmt.Errorf(
+				"Resource limit %s was not restored to %s: %d", rs.Primary.ID, previous, limit.Max)
+		}
+	}
+
+	return nil
+}
+
+func testAccGetResourceLimit(rs *terraform.ResourceState) (*cloudstack.ResourceLimit, error) {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	projectid, e := retrieveID(cs, "project", rs.Primary.Attributes["project"])
+	if e != nil {
+		return nil, e.Error()
+	}
+
+	p := cs.Limit.NewListResourceLimitsParams()
+	p.SetResourcetype(resourceLimitTypes[rs.Primary.Attributes["type"]])
+	p.SetProjectid(projectid)
+
+	l, err := cs.Limit.ListResourceLimits(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.Count == 0 {
+		return nil, fmt.Errorf("Resource limit not found")
+	}
+
+	return l.ResourceLimits[0], nil
+}
+
+func testAccCloudStackResourceLimit_basic(max int) string {
+	return fmt.Sprintf(`
+resource "cloudstack_resource_limit" "foo" {
+  type = "instance"
+  max = %d
+  project = "terraform"
+}`, max)
+}
@@ -0,0 +1,322 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackRouterSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackRouterSettingsCreate,
+		Read:   resourceCloudStackRouterSettingsRead,
+		Update: resourceCloudStackRouterSettingsUpdate,
+		Delete: resourceCloudStackRouterSettingsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"network_id", "vpc_id"},
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"service_offering": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"force_stop": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"reboot_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"upgrade_template": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"running_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  600,
+			},
+
+			"router_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCloudStackRouterSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+	if v, ok := d.GetOk("network_id"); ok {
+		d.SetId(v.(string))
+	} else {
+		d.SetId(d.Get("vpc_id").(string))
+	}
+
+	return resourceCloudStackRouterSettingsUpdate(d, meta)
+}
+
+func resourceCloudStackRouterSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	routers, err := listRouters(cs, d)
+	if err != nil {
+		return err
+	}
+
+	if len(routers) == 0 {
+		log.Printf("[DEBUG] Routers of network or VPC %s do no longer exist", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	var ids []string
+	state := "Running"
+	requiresUpgrade := false
+
+	for _, r := range routers {
+		ids = append(ids, r.Id)
+
+		if r.State != "Running" {
+			state = r.State
+		}
+
+		if r.Requiresupgrade {
+			requiresUpgrade = true
+		}
+	}
+
+	if err := d.Set("router_ids", ids); err != nil {
+		return err
+	}
+
+	// Only track the state when it is managed, so partially stopped
+	// redundant routers don't cause a diff
+	if _, ok := d.GetOk("state"); ok {
+		if err := d.Set("state", state); err != nil {
+			return err
+		}
+	}
+
+	// Make sure a pending template upgrade shows up as a diff
+	if requiresUpgrade {
+		if err := d.Set("upgrade_template", false); err != nil {
+			return err
+		}
+	}
+
+	setValueOrID(d, "service_offering", routers[0].Serviceofferingname, routers[0].Serviceofferingid)
+
+	return nil
+}
+
+func resourceCloudStackRouterSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyRouterSettingsParams(d); err != nil {
+		return err
+	}
+
+	routers, err := listRouters(cs, d)
+	if err != nil {
+		return err
+	}
+
+	if len(routers) == 0 {
+		return fmt.Errorf("No router found for network or VPC %s", d.Id())
+	}
+
+	state := d.Get("state").(string)
+	reboot := !d.IsNewResource() && d.HasChange("reboot_trigger")
+
+	var serviceofferingid string
+	if v, ok := d.GetOk("service_offering"); ok && d.HasChange("service_offering") {
+		id, e := retrieveID(cs, "service_offering", v.(string))
+		if e != nil {
+			return e.Error()
+		}
+		serviceofferingid = id
+	}
+
+	var running []string
+	for _, r := range routers {
+		wantRunning := state == "Running" || (state == "" && r.State == "Running")
+		if wantRunning {
+			running = append(running, r.Id)
+		}
+
+		// A router needs to be stopped before its service offering can be changed
+		if serviceofferingid != "" && r.Serviceofferingid != serviceofferingid {
+			if r.State != "Stopped" {
+				if err := stopRouter(d, meta, r.Id); err != nil {
+					return err
+				}
+				r.State = "Stopped"
+			}
+
+			log.Printf("[DEBUG] Changing service offering of router %s", r.Id)
+			p := cs.Router.NewChangeServiceForRouterParams(r.Id, serviceofferingid)
+			if _, err := cs.Router.ChangeServiceForRouter(p); err != nil {
+				return fmt.Errorf(
+					"Error changing service offering of router %s: %s", r.Id, err)
+			}
+		}
+
+		// Upgrading the template reboots the router
+		if d.Get("upgrade_template").(bool) && r.Requiresupgrade {
+			log.Printf("[DEBUG] Upgrading template of router %s", r.Id)
+			p := cs.Template.NewUpgradeRouterTemplateParams()
+			p.SetId(r.Id)
+
+			if _, err := cs.Template.UpgradeRouterTemplate(p); err != nil {
+				return fmt.Errorf("Error upgrading template of router %s: %s", r.Id, err)
+			}
+
+			if wantRunning && r.State == "Running" {
+				continue
+			}
+		}
+
+		switch {
+		case wantRunning && r.State != "Running":
+			log.Printf("[DEBUG] Starting router %s", r.Id)
+			if _, err := cs.Router.StartRouter(cs.Router.NewStartRouterParams(r.Id)); err != nil {
+				return fmt.Errorf("Error starting router %s: %s", r.Id, err)
+			}
+		case !wantRunning && r.State == "Running":
+			if err := stopRouter(d, meta, r.Id); err != nil {
+				return err
+			}
+		case wantRunning && reboot:
+			log.Printf("[DEBUG] Rebooting router %s", r.Id)
+			if _, err := cs.Router.RebootRouter(cs.Router.NewRebootRouterParams(r.Id)); err != nil {
+				return fmt.Errorf("Error rebooting router %s: %s", r.Id, err)
+			}
+		}
+	}
+
+	if err := waitForRoutersRunning(d, meta, running); err != nil {
+		return err
+	}
+
+	return resourceCloudStackRouterSettingsRead(d, meta)
+}
+
+func resourceCloudStackRouterSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	// The routers belong to the network or VPC, so there is nothing to delete
+	d.SetId("")
+
+	return nil
+}
+
+func listRouters(cs *cloudstack.CloudStackClient, d *schema.ResourceData) ([]*cloudstack.Router, error) {
+	p := cs.Router.NewListRoutersParams()
+	p.SetListall(true)
+
+	if v, ok := d.GetOk("network_id"); ok {
+		p.SetNetworkid(v.(string))
+	}
+
+	if v, ok := d.GetOk("vpc_id"); ok {
+		p.SetVpcid(v.(string))
+	}
+
+	if err := setProjectid(p, cs, d); err != nil {
+		return nil, err
+	}
+
+	l, err := cs.Router.ListRouters(p)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing routers: %s", err)
+	}
+
+	return l.Routers, nil
+}
+
+func stopRouter(d *schema.ResourceData, meta interface{}, id string) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	p := cs.Router.NewStopRouterParams(id)
+	p.SetForced(d.Get("force_stop").(bool))
+
+	log.Printf("[DEBUG] Stopping router %s", id)
+	if _, err := cs.Router.StopRouter(p); err != nil {
+		return fmt.Errorf("Error stopping router %s: %s", id, err)
+	}
+
+	return nil
+}
+
+func waitForRoutersRunning(d *schema.ResourceData, meta interface{}, ids []string) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	currentTime := time.Now().Unix()
+	timeout := int64(d.Get("running_timeout").(int))
+	for {
+		running := true
+		for _, id := range ids {
+			r, _, err := cs.Router.GetRouterByID(id)
+			if err != nil {
+				return err
+			}
+
+			if r.State != "Running" {
+				running = false
+			}
+		}
+
+		if running {
+			return nil
+		}
+
+		if time.Now().Unix()-currentTime > timeout {
+			return fmt.Errorf("Timeout while waiting for the routers of %s to be running", d.Id())
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func verifyRouterSettingsParams(d *schema.ResourceData) error {
+	state := d.Get("state").(string)
+	if state != "" && state != "Running" && state != "Stopped" {
+		return fmt.Errorf(
+			"%q is not a valid state. Valid options are 'Running' and 'Stopped'", state)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackRouterSettings_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackRouterSettings_basic("Running", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackRouterSettingsState(
+						"cloudstack_router_settings.foo", "Running"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_router_settings.foo", "router_ids.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.cloudstack_router.foo", "routers.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackRouterSettings_basic("Stopped", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackRouterSettingsState(
+						"cloudstack_router_settings.foo", "Stopped"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackRouterSettings_basic("Running", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackRouterSettingsState(
+						"cloudstack_router_settings.foo", "Running"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackRouterSettingsState(n string, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No network ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+		p := cs.Router.NewListRoutersParams()
+		p.SetNetworkid(rs.Primary.ID)
+
+		l, err := cs.Router.ListRouters(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count == 0 {
+			return fmt.Errorf("Router not found")
+		}
+
+		for _, r := range l.Routers {
+			if r.State != state {
+				return fmt.Errorf("Router %s is %s, expected %s", r.Id, r.State, state)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCloudStackRouterSettings_basic(state, trigger string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_network" "foo" {
+  name = "terraform-network"
+  cidr = "10.1.1.0/24"
+  network_offering = "DefaultIsolatedNetworkOfferingWithSourceNatService"
+  zone = "Sandbox-simulator"
+}
+
+resource "cloudstack_instance" "foobar" {
+  name = "terraform-test"
+  service_offering= "Small Instance"
+  network_id = "${cloudstack_network.foo.id}"
+  template = "CentOS 5.6 (64-bit) no GUI (Simulator)"
+  zone = "Sandbox-simulator"
+  expunge = true
+}
+
+resource "cloudstack_router_settings" "foo" {
+  network_id = "${cloudstack_instance.foobar.network_id}"
+  state = "%s"
+  reboot_trigger = "%s"
+}
+
+data "cloudstack_router" "foo" {
+  network_id = "${cloudstack_router_settings.foo.id}"
+}`, state, trigger)
+}
@@ -0,0 +1,171 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackSSLCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackSSLCertificateCreate,
+		Read:   resourceCloudStackSSLCertificateRead,
+		Delete: resourceCloudStackSSLCertificateDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"certificate": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"certificate_chain": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackSSLCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewUploadSslCertParams(
+		d.Get("certificate").(string),
+		name,
+		d.Get("private_key").(string),
+	)
+
+	if chain, ok := d.GetOk("certificate_chain"); ok {
+		p.SetCertchain(chain.(string))
+	}
+
+	if password, ok := d.GetOk("password"); ok {
+		p.SetPassword(password.(string))
+	}
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Upload the new certificate
+	r, err := cs.LoadBalancer.UploadSslCert(p)
+	if err != nil {
+		return fmt.Errorf("Error uploading SSL certificate %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackSSLCertificateRead(d, meta)
+}
+
+func resourceCloudStackSSLCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewListSslCertsParams()
+	p.SetCertid(d.Id())
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Get the certificate details
+	l, err := cs.LoadBalancer.ListSslCerts(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			log.Printf("[DEBUG] SSL certificate %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if l.Count == 0 {
+		log.Printf("[DEBUG] SSL certificate %s does no longer exist", d.Get("name").(string))
+		d.SetId("")
+		return nil
+	}
+
+	c := l.SslCerts[0]
+
+	if err := d.Set("name", c.Name); err != nil {
+		return err
+	}
+	if err := d.Set("fingerprint", c.Fingerprint); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "project", c.Project, c.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackSSLCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.LoadBalancer.NewDeleteSslCertParams(d.Id())
+
+	// Delete the certificate
+	log.Printf("[INFO] Deleting SSL certificate: %s", d.Get("name").(string))
+	_, err := cs.LoadBalancer.DeleteSslCert(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting SSL certificate %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
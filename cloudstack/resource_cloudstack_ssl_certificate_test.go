@@ -0,0 +1,142 @@
+package cloudstack
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackSSLCertificate_basic(t *testing.T) {
+	var cert cloudstack.SslCert
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackSSLCertificateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackSSLCertificate_basic(t),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackSSLCertificateExists(
+						"cloudstack_ssl_certificate.foo", &cert),
+					resource.TestCheckResourceAttr(
+						"cloudstack_ssl_certificate.foo", "name", "terraform-test"),
+					resource.TestCheckResourceAttrSet(
+						"cloudstack_ssl_certificate.foo", "fingerprint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackSSLCertificateExists(
+	n string, cert *cloudstack.SslCert) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSL certificate ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		p := cs.LoadBalancer.NewListSslCertsParams()
+		p.SetCertid(rs.Primary.ID)
+
+		l, err := cs.LoadBalancer.ListSslCerts(p)
+		if err != nil {
+			return err
+		}
+
+		if l.Count != 1 || l.SslCerts[0].Id != rs.Primary.ID {
+			return fmt.Errorf("SSL certificate not found")
+		}
+
+		*cert = *l.SslCerts[0]
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackSSLCertificateDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_ssl_certificate" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No SSL certificate ID is set")
+		}
+
+		p := cs.LoadBalancer.NewListSslCertsParams()
+		p.SetCertid(rs.Primary.ID)
+
+		l, err := cs.LoadBalancer.ListSslCerts(p)
+		if err == nil && l.Count > 0 {
+			return fmt.Errorf("SSL certificate %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+// testAccGenerateSSLCertificate returns a PEM encoded self-signed certificate
+// and its matching private key.
+func testAccGenerateSSLCertificate(t *testing.T, cn string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating private key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{cn},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error generating certificate: %s", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error encoding private key: %s", err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	pkey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+
+	return string(cert), string(pkey)
+}
+
+func testAccCloudStackSSLCertificate_basic(t *testing.T) string {
+	cert, key := testAccGenerateSSLCertificate(t, "terraform.example.com")
+
+	return fmt.Sprintf(`
+resource "cloudstack_ssl_certificate" "foo" {
+  name = "terraform-test"
+  certificate = <<EOT
+%sEOT
+  private_key = <<EOT
+%sEOT
+}`, cert, key)
+}
@@ -0,0 +1,308 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackStoragePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackStoragePoolCreate,
+		Read:   resourceCloudStackStoragePoolRead,
+		Update: resourceCloudStackStoragePoolUpdate,
+		Delete: resourceCloudStackStoragePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"pod_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"cluster_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"scope": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"hypervisor": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"provider_name": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"storage_tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"capacity_bytes": {
+				Type:       schema.TypeInt,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"capacity_iops": {
+				Type:       schema.TypeInt,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackStoragePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Retrieve the zone ID
+	zoneid, e := retrieveID(cs, "zone", d.Get("zone").(string))
+	if e != nil {
+		return e.Error()
+	}
+
+	// Create a new parameter struct
+	p := cs.Pool.NewCreateStoragePoolParams(name, d.Get("url").(string), zoneid)
+
+	if v, ok := d.GetOk("pod_id"); ok {
+		p.SetPodid(v.(string))
+	}
+
+	if v, ok := d.GetOk("cluster_id"); ok {
+		p.SetClusterid(v.(string))
+	}
+
+	if v, ok := d.GetOk("scope"); ok {
+		p.SetScope(v.(string))
+	}
+
+	if v, ok := d.GetOk("hypervisor"); ok {
+		p.SetHypervisor(v.(string))
+	}
+
+	if v, ok := d.GetOk("provider_name"); ok {
+		p.SetProvider(v.(string))
+	}
+
+	if v, ok := d.GetOk("storage_tags"); ok {
+		var tags []string
+		for _, t := range v.(*schema.Set).List() {
+			tags = append(tags, t.(string))
+		}
+		p.SetTags(strings.Join(tags, ","))
+	}
+
+	if v, ok := d.GetOk("capacity_bytes"); ok {
+		p.SetCapacitybytes(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("capacity_iops"); ok {
+		p.SetCapacityiops(int64(v.(int)))
+	}
+
+	// Create the new storage pool
+	r, err := cs.Pool.CreateStoragePool(p)
+	if err != nil {
+		return fmt.Errorf("Error creating storage pool %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	// A new storage pool is always enabled
+	if !d.Get("enabled").(bool) {
+		up := cs.Pool.NewUpdateStoragePoolParams(d.Id())
+		up.SetEnabled(false)
+
+		if _, err := cs.Pool.UpdateStoragePool(up); err != nil {
+			return fmt.Errorf("Error disabling storage pool %s: %s", name, err)
+		}
+	}
+
+	return resourceCloudStackStoragePoolRead(d, meta)
+}
+
+func resourceCloudStackStoragePoolRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the storage pool details
+	pool, count, err := cs.Pool.GetStoragePoolByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Storage pool %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", pool.Name); err != nil {
+		return err
+	}
+	if err := d.Set("pod_id", pool.Podid); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_id", pool.Clusterid); err != nil {
+		return err
+	}
+	if err := d.Set("scope", pool.Scope); err != nil {
+		return err
+	}
+	if err := d.Set("hypervisor", pool.Hypervisor); err != nil {
+		return err
+	}
+	if err := d.Set("provider_name", pool.Provider); err != nil {
+		return err
+	}
+	if err := d.Set("capacity_bytes", int(pool.Disksizetotal)); err != nil {
+		return err
+	}
+	if err := d.Set("capacity_iops", int(pool.Capacityiops)); err != nil {
+		return err
+	}
+	if err := d.Set("enabled", pool.State != "Disabled"); err != nil {
+		return err
+	}
+	if err := d.Set("state", pool.State); err != nil {
+		return err
+	}
+
+	var tags []interface{}
+	if pool.Tags != "" {
+		for _, t := range strings.Split(pool.Tags, ",") {
+			tags = append(tags, t)
+		}
+	}
+	if err := d.Set("storage_tags", schema.NewSet(schema.HashString, tags)); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "zone", pool.Zonename, pool.Zoneid)
+
+	return nil
+}
+
+func resourceCloudStackStoragePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Pool.NewUpdateStoragePoolParams(d.Id())
+
+	if d.HasChange("name") {
+		p.SetName(name)
+	}
+
+	if d.HasChange("storage_tags") {
+		var tags []string
+		for _, t := range d.Get("storage_tags").(*schema.Set).List() {
+			tags = append(tags, t.(string))
+		}
+		p.SetTags(tags)
+	}
+
+	if d.HasChange("capacity_bytes") {
+		p.SetCapacitybytes(int64(d.Get("capacity_bytes").(int)))
+	}
+
+	if d.HasChange("capacity_iops") {
+		p.SetCapacityiops(int64(d.Get("capacity_iops").(int)))
+	}
+
+	if d.HasChange("enabled") {
+		p.SetEnabled(d.Get("enabled").(bool))
+	}
+
+	log.Printf("[DEBUG] Updating storage pool %s", name)
+	if _, err := cs.Pool.UpdateStoragePool(p); err != nil {
+		return fmt.Errorf("Error updating storage pool %s: %s", name, err)
+	}
+
+	return resourceCloudStackStoragePoolRead(d, meta)
+}
+
+func resourceCloudStackStoragePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Pool.NewDeleteStoragePoolParams(d.Id())
+
+	// Delete the storage pool
+	log.Printf("[INFO] Deleting storage pool: %s", d.Get("name").(string))
+	_, err := cs.Pool.DeleteStoragePool(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting storage pool %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackStoragePool_basic(t *testing.T) {
+	var pool cloudstack.StoragePool
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackStoragePoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackStoragePool_basic("terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackStoragePoolExists(
+						"cloudstack_storage_pool.foo", &pool),
+					resource.TestCheckResourceAttr(
+						"cloudstack_storage_pool.foo", "scope", "CLUSTER"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_storage_pool.foo", "storage_tags.#", "1"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackStoragePool_basic("terraform-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackStoragePoolExists(
+						"cloudstack_storage_pool.foo", &pool),
+					resource.TestCheckResourceAttr(
+						"cloudstack_storage_pool.foo", "storage_tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackStoragePoolExists(
+	n string, pool *cloudstack.StoragePool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No storage pool ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		p, _, err := cs.Pool.GetStoragePoolByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if p.Id != rs.Primary.ID {
+			return fmt.Errorf("Storage pool not found")
+		}
+
+		*pool = *p
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackStoragePoolDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_storage_pool" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No storage pool ID is set")
+		}
+
+		_, _, err := cs.Pool.GetStoragePoolByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Storage pool %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCloudStackStoragePool_basic(tag string) string {
+	return fmt.Sprintf(`
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+}
+
+resource "cloudstack_pod" "foo" {
+  name = "terraform-pod"
+  zone = cloudstack_zone.foo.name
+  gateway = "192.168.100.1"
+  netmask = "255.255.255.0"
+  start_ip = "192.168.100.10"
+  end_ip = "192.168.100.50"
+}
+
+resource "cloudstack_cluster" "foo" {
+  name = "terraform-cluster"
+  hypervisor = "Simulator"
+  pod_id = cloudstack_pod.foo.id
+  zone = cloudstack_zone.foo.name
+}
+
+resource "cloudstack_storage_pool" "foo" {
+  name = "terraform-primary"
+  url = "nfs://10.147.28.6/export/home/sandbox/terraform-primary"
+  zone = cloudstack_zone.foo.name
+  pod_id = cloudstack_pod.foo.id
+  cluster_id = cloudstack_cluster.foo.id
+  scope = "CLUSTER"
+  storage_tags = ["%s"]
+}`, tag)
+}
@@ -0,0 +1,397 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackVLANIPRange() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackVLANIPRangeCreate,
+		Read:   resourceCloudStackVLANIPRangeRead,
+		Update: resourceCloudStackVLANIPRangeUpdate,
+		Delete: resourceCloudStackVLANIPRangeDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"pod_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"physical_network_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"network_id": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"vlan": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"start_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"end_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"ip6_gateway": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"ip6_cidr": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"start_ipv6": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"end_ipv6": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"for_virtual_network": {
+				Type:       schema.TypeBool,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+
+			"for_system_vms": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"account": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				RequiredWith:  []string{"domain_id"},
+				ConflictsWith: []string{"project"},
+			},
+
+			"domain_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"project": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				RequiredWith:  []string{"domain_id"},
+				ConflictsWith: []string{"account"},
+			},
+		},
+	}
+}
+
+func resourceCloudStackVLANIPRangeCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VLAN.NewCreateVlanIpRangeParams()
+
+	// Retrieve the zone ID
+	if zone, ok := d.GetOk("zone"); ok {
+		zoneid, e := retrieveID(cs, "zone", zone.(string))
+		if e != nil {
+			return e.Error()
+		}
+		p.SetZoneid(zoneid)
+	}
+
+	if v, ok := d.GetOk("pod_id"); ok {
+		p.SetPodid(v.(string))
+	}
+
+	if v, ok := d.GetOk("physical_network_id"); ok {
+		p.SetPhysicalnetworkid(v.(string))
+	}
+
+	if v, ok := d.GetOk("network_id"); ok {
+		p.SetNetworkid(v.(string))
+	}
+
+	if v, ok := d.GetOk("vlan"); ok {
+		p.SetVlan(v.(string))
+	}
+
+	if v, ok := d.GetOk("gateway"); ok {
+		p.SetGateway(v.(string))
+	}
+
+	if v, ok := d.GetOk("netmask"); ok {
+		p.SetNetmask(v.(string))
+	}
+
+	if v, ok := d.GetOk("start_ip"); ok {
+		p.SetStartip(v.(string))
+	}
+
+	if v, ok := d.GetOk("end_ip"); ok {
+		p.SetEndip(v.(string))
+	}
+
+	if v, ok := d.GetOk("ip6_gateway"); ok {
+		p.SetIp6gateway(v.(string))
+	}
+
+	if v, ok := d.GetOk("ip6_cidr"); ok {
+		p.SetIp6cidr(v.(string))
+	}
+
+	if v, ok := d.GetOk("start_ipv6"); ok {
+		p.SetStartipv6(v.(string))
+	}
+
+	if v, ok := d.GetOk("end_ipv6"); ok {
+		p.SetEndipv6(v.(string))
+	}
+
+	if v, ok := d.GetOk("for_virtual_network"); ok {
+		p.SetForvirtualnetwork(v.(bool))
+	}
+
+	p.SetForsystemvms(d.Get("for_system_vms").(bool))
+
+	// Dedicate the range to the account or project, if supplied
+	if v, ok := d.GetOk("domain_id"); ok {
+		p.SetDomainid(v.(string))
+	}
+
+	if v, ok := d.GetOk("account"); ok {
+		p.SetAccount(v.(string))
+	}
+
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Create the new VLAN IP range
+	r, err := cs.VLAN.CreateVlanIpRange(p)
+	if err != nil {
+		return fmt.Errorf("Error creating VLAN IP range: %s", err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackVLANIPRangeRead(d, meta)
+}
+
+func resourceCloudStackVLANIPRangeRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the VLAN IP range details
+	v, count, err := cs.VLAN.GetVlanIpRangeByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] VLAN IP range %s does no longer exist", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("pod_id", v.Podid); err != nil {
+		return err
+	}
+	if err := d.Set("physical_network_id", v.Physicalnetworkid); err != nil {
+		return err
+	}
+	if err := d.Set("network_id", v.Networkid); err != nil {
+		return err
+	}
+
+	// The API returns the VLAN as an URI, so strip the scheme unless the
+	// VLAN was configured as an URI as well
+	vlan := v.Vlan
+	if !strings.HasPrefix(d.Get("vlan").(string), "vlan://") {
+		vlan = strings.TrimPrefix(vlan, "vlan://")
+	}
+	if err := d.Set("vlan", vlan); err != nil {
+		return err
+	}
+
+	if err := d.Set("gateway", v.Gateway); err != nil {
+		return err
+	}
+	if err := d.Set("netmask", v.Netmask); err != nil {
+		return err
+	}
+	if err := d.Set("start_ip", v.Startip); err != nil {
+		return err
+	}
+	if err := d.Set("end_ip", v.Endip); err != nil {
+		return err
+	}
+	if err := d.Set("ip6_gateway", v.Ip6gateway); err != nil {
+		return err
+	}
+	if err := d.Set("ip6_cidr", v.Ip6cidr); err != nil {
+		return err
+	}
+	if err := d.Set("start_ipv6", v.Startipv6); err != nil {
+		return err
+	}
+	if err := d.Set("end_ipv6", v.Endipv6); err != nil {
+		return err
+	}
+	if err := d.Set("for_virtual_network", v.Forvirtualnetwork); err != nil {
+		return err
+	}
+	if err := d.Set("for_system_vms", v.Forsystemvms); err != nil {
+		return err
+	}
+
+	// Only a dedicated range has an owner
+	if v.Account != "" || v.Projectid != "" {
+		if err := d.Set("domain_id", v.Domainid); err != nil {
+			return err
+		}
+		if err := d.Set("account", v.Account); err != nil {
+			return err
+		}
+		setValueOrID(d, "project", v.Project, v.Projectid)
+	} else {
+		if err := d.Set("domain_id", ""); err != nil {
+			return err
+		}
+		if err := d.Set("account", ""); err != nil {
+			return err
+		}
+		if err := d.Set("project", ""); err != nil {
+			return err
+		}
+	}
+
+	// The API only returns the zone ID, so a configured zone name is kept
+	if zone := d.Get("zone").(string); zone == "" || cloudstack.IsID(zone) {
+		if err := d.Set("zone", v.Zoneid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceCloudStackVLANIPRangeUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if d.HasChange("domain_id") || d.HasChange("account") || d.HasChange("project") {
+		// Release the current dedication first
+		o, _ := d.GetChange("domain_id")
+		if o.(string) != "" {
+			log.Printf("[DEBUG] Releasing dedication of VLAN IP range %s", d.Id())
+
+			p := cs.Network.NewReleasePublicIpRangeParams(d.Id())
+			if _, err := cs.Network.ReleasePublicIpRange(p); err != nil {
+				return fmt.Errorf("Error releasing VLAN IP range %s: %s", d.Id(), err)
+			}
+		}
+
+		// Then dedicate the range to the new owner
+		if domainid, ok := d.GetOk("domain_id"); ok {
+			log.Printf("[DEBUG] Dedicating VLAN IP range %s", d.Id())
+
+			p := cs.Network.NewDedicatePublicIpRangeParams(domainid.(string), d.Id())
+
+			if account, ok := d.GetOk("account"); ok {
+				p.SetAccount(account.(string))
+			}
+
+			if err := setProjectid(p, cs, d); err != nil {
+				return err
+			}
+
+			if _, err := cs.Network.DedicatePublicIpRange(p); err != nil {
+				return fmt.Errorf("Error dedicating VLAN IP range %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceCloudStackVLANIPRangeRead(d, meta)
+}
+
+func resourceCloudStackVLANIPRangeDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VLAN.NewDeleteVlanIpRangeParams(d.Id())
+
+	// Delete the VLAN IP range
+	log.Printf("[INFO] Deleting VLAN IP range: %s", d.Id())
+	_, err := cs.VLAN.DeleteVlanIpRange(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting VLAN IP range %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
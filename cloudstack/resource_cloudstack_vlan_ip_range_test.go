@@ -0,0 +1,129 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackVLANIPRange_basic(t *testing.T) {
+	var vlan cloudstack.VlanIpRange
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackVLANIPRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackVLANIPRange_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackVLANIPRangeExists(
+						"cloudstack_vlan_ip_range.foo", &vlan),
+					testAccCheckCloudStackVLANIPRangeAttributes(&vlan),
+					resource.TestCheckResourceAttr(
+						"cloudstack_vlan_ip_range.foo", "vlan", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackVLANIPRange_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackVLANIPRangeDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackVLANIPRange_basic,
+			},
+
+			{
+				ResourceName:            "cloudstack_vlan_ip_range.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"zone"},
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackVLANIPRangeExists(
+	n string, vlan *cloudstack.VlanIpRange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VLAN IP range ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		v, _, err := cs.VLAN.GetVlanIpRangeByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if v.Id != rs.Primary.ID {
+			return fmt.Errorf("VLAN IP range not found")
+		}
+
+		*vlan = *v
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackVLANIPRangeAttributes(
+	vlan *cloudstack.VlanIpRange) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if vlan.Startip != "10.100.0.10" {
+			return fmt.Errorf("Bad start IP: %s", vlan.Startip)
+		}
+
+		if vlan.Endip != "10.100.0.20" {
+			return fmt.Errorf("Bad end IP: %s", vlan.Endip)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackVLANIPRangeDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_vlan_ip_range" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VLAN IP range ID is set")
+		}
+
+		_, _, err := cs.VLAN.GetVlanIpRangeByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VLAN IP range %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackVLANIPRange_basic = `
+resource "cloudstack_vlan_ip_range" "foo" {
+  zone = "Sandbox-simulator"
+  vlan = "300"
+  gateway = "10.100.0.1"
+  netmask = "255.255.255.0"
+  start_ip = "10.100.0.10"
+  end_ip = "10.100.0.20"
+  for_virtual_network = true
+}`
@@ -0,0 +1,111 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackVPNUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackVPNUserCreate,
+		Read:   resourceCloudStackVPNUserRead,
+		Delete: resourceCloudStackVPNUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+
+			"project": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+				ForceNew:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackVPNUserCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	username := d.Get("username").(string)
+
+	// Create a new parameter struct
+	p := cs.VPN.NewAddVpnUserParams(d.Get("password").(string), username)
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Add the new VPN user
+	u, err := cs.VPN.AddVpnUser(p)
+	if err != nil {
+		return fmt.Errorf("Error adding VPN user %s: %s", username, err)
+	}
+
+	d.SetId(u.Id)
+
+	return resourceCloudStackVPNUserRead(d, meta)
+}
+
+func resourceCloudStackVPNUserRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the VPN user details
+	u, count, err := cs.VPN.GetVpnUserByID(
+		d.Id(),
+		cloudstack.WithProject(d.Get("project").(string)),
+	)
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] VPN user %s does no longer exist", d.Get("username").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("username", u.Username); err != nil {
+		return err
+	}
+
+	setValueOrID(d, "project", u.Project, u.Projectid)
+
+	return nil
+}
+
+func resourceCloudStackVPNUserDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.VPN.NewRemoveVpnUserParams(d.Get("username").(string))
+
+	// If there is a project supplied, we retrieve and set the project id
+	if err := setProjectid(p, cs, d); err != nil {
+		return err
+	}
+
+	// Remove the VPN user
+	log.Printf("[INFO] Removing VPN user: %s", d.Get("username").(string))
+	if _, err := cs.VPN.RemoveVpnUser(p); err != nil {
+		return fmt.Errorf("Error removing VPN user %s: %s", d.Get("username").(string), err)
+	}
+
+	return nil
+}
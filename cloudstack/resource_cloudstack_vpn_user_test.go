@@ -0,0 +1,87 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackVPNUser_basic(t *testing.T) {
+	var user cloudstack.VpnUser
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackVPNUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackVPNUser_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackVPNUserExists(
+						"cloudstack_vpn_user.foo", &user),
+					resource.TestCheckResourceAttr(
+						"cloudstack_vpn_user.foo", "username", "terraform-user"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackVPNUserExists(
+	n string, user *cloudstack.VpnUser) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VPN user ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		u, _, err := cs.VPN.GetVpnUserByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if u.Id != rs.Primary.ID {
+			return fmt.Errorf("VPN user not found")
+		}
+
+		*user = *u
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackVPNUserDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_vpn_user" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No VPN user ID is set")
+		}
+
+		_, _, err := cs.VPN.GetVpnUserByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("VPN user %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackVPNUser_basic = `
+resource "cloudstack_vpn_user" "foo" {
+  username = "terraform-user"
+  password = "terraform-password"
+}`
@@ -0,0 +1,333 @@
+package cloudstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCloudStackZone() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudStackZoneCreate,
+		Read:   resourceCloudStackZoneRead,
+		Update: resourceCloudStackZoneUpdate,
+		Delete: resourceCloudStackZoneDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"network_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"dns1": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"dns2": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"internal_dns1": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"internal_dns2": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ip6_dns1": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ip6_dns2": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"network_domain": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"guest_cidr_address": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+
+			"local_storage_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"security_group_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"allocation_state": {
+				Type:       schema.TypeString,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Optional:   true,
+				Computed:   true,
+			},
+		},
+	}
+}
+
+func resourceCloudStackZoneCreate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyZoneParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Zone.NewCreateZoneParams(
+		d.Get("dns1").(string),
+		d.Get("internal_dns1").(string),
+		name,
+		d.Get("network_type").(string),
+	)
+
+	if v, ok := d.GetOk("dns2"); ok {
+		p.SetDns2(v.(string))
+	}
+
+	if v, ok := d.GetOk("internal_dns2"); ok {
+		p.SetInternaldns2(v.(string))
+	}
+
+	if v, ok := d.GetOk("ip6_dns1"); ok {
+		p.SetIp6dns1(v.(string))
+	}
+
+	if v, ok := d.GetOk("ip6_dns2"); ok {
+		p.SetIp6dns2(v.(string))
+	}
+
+	if v, ok := d.GetOk("network_domain"); ok {
+		p.SetDomain(v.(string))
+	}
+
+	if v, ok := d.GetOk("guest_cidr_address"); ok {
+		p.SetGuestcidraddress(v.(string))
+	}
+
+	if v, ok := d.GetOk("allocation_state"); ok {
+		p.SetAllocationstate(v.(string))
+	}
+
+	p.SetLocalstorageenabled(d.Get("local_storage_enabled").(bool))
+	p.SetSecuritygroupenabled(d.Get("security_group_enabled").(bool))
+
+	// Create the new zone
+	r, err := cs.Zone.CreateZone(p)
+	if err != nil {
+		return fmt.Errorf("Error creating zone %s: %s", name, err)
+	}
+
+	d.SetId(r.Id)
+
+	return resourceCloudStackZoneRead(d, meta)
+}
+
+func resourceCloudStackZoneRead(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Get the zone details
+	z, count, err := cs.Zone.GetZoneByID(d.Id())
+	if err != nil {
+		if count == 0 {
+			log.Printf("[DEBUG] Zone %s does no longer exist", d.Get("name").(string))
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	if err := d.Set("name", z.Name); err != nil {
+		return err
+	}
+	if err := d.Set("network_type", z.Networktype); err != nil {
+		return err
+	}
+	if err := d.Set("dns1", z.Dns1); err != nil {
+		return err
+	}
+	if err := d.Set("dns2", z.Dns2); err != nil {
+		return err
+	}
+	if err := d.Set("internal_dns1", z.Internaldns1); err != nil {
+		return err
+	}
+	if err := d.Set("internal_dns2", z.Internaldns2); err != nil {
+		return err
+	}
+	if err := d.Set("ip6_dns1", z.Ip6dns1); err != nil {
+		return err
+	}
+	if err := d.Set("ip6_dns2", z.Ip6dns2); err != nil {
+		return err
+	}
+	if err := d.Set("network_domain", z.Domain); err != nil {
+		return err
+	}
+	if err := d.Set("guest_cidr_address", z.Guestcidraddress); err != nil {
+		return err
+	}
+	if err := d.Set("local_storage_enabled", z.Localstorageenabled); err != nil {
+		return err
+	}
+	if err := d.Set("security_group_enabled", z.Securitygroupsenabled); err != nil {
+		return err
+	}
+	if err := d.Set("allocation_state", z.Allocationstate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceCloudStackZoneUpdate(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	if err := verifyZoneParams(d); err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Create a new parameter struct
+	p := cs.Zone.NewUpdateZoneParams(d.Id())
+
+	if d.HasChange("name") {
+		p.SetName(name)
+	}
+
+	if d.HasChange("dns1") {
+		p.SetDns1(d.Get("dns1").(string))
+	}
+
+	if d.HasChange("dns2") {
+		p.SetDns2(d.Get("dns2").(string))
+	}
+
+	if d.HasChange("internal_dns1") {
+		p.SetInternaldns1(d.Get("internal_dns1").(string))
+	}
+
+	if d.HasChange("internal_dns2") {
+		p.SetInternaldns2(d.Get("internal_dns2").(string))
+	}
+
+	if d.HasChange("ip6_dns1") {
+		p.SetIp6dns1(d.Get("ip6_dns1").(string))
+	}
+
+	if d.HasChange("ip6_dns2") {
+		p.SetIp6dns2(d.Get("ip6_dns2").(string))
+	}
+
+	if d.HasChange("network_domain") {
+		p.SetDomain(d.Get("network_domain").(string))
+	}
+
+	if d.HasChange("guest_cidr_address") {
+		p.SetGuestcidraddress(d.Get("guest_cidr_address").(string))
+	}
+
+	if d.HasChange("local_storage_enabled") {
+		p.SetLocalstorageenabled(d.Get("local_storage_enabled").(bool))
+	}
+
+	if d.HasChange("allocation_state") {
+		p.SetAllocationstate(d.Get("allocation_state").(string))
+	}
+
+	log.Printf("[DEBUG] Updating zone %s", name)
+	if _, err := cs.Zone.UpdateZone(p); err != nil {
+		return fmt.Errorf("Error updating zone %s: %s", name, err)
+	}
+
+	return resourceCloudStackZoneRead(d, meta)
+}
+
+func resourceCloudStackZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	cs := meta.(*cloudstack.CloudStackClient)
+
+	// Create a new parameter struct
+	p := cs.Zone.NewDeleteZoneParams(d.Id())
+
+	// Delete the zone
+	log.Printf("[INFO] Deleting zone: %s", d.Get("name").(string))
+	_, err := cs.Zone.DeleteZone(p)
+	if err != nil {
+		// This is a very poor way to be told the ID does no longer exist :(
+		if strings.Contains(err.Error(), fmt.Sprintf(
+			"Invalid parameter id value=%s due to incorrect long value format, "+
+				"or entity does not exist", d.Id())) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting zone %s: %s", d.Get("name").(string), err)
+	}
+
+	return nil
+}
+
+func verifyZoneParams(d *schema.ResourceData) error {
+	networkType := d.Get("network_type").(string)
+	if networkType != "Basic" && networkType != "Advanced" {
+		return fmt.Errorf(
+			"%q is not a valid network type. Valid options are 'Basic' and 'Advanced'", networkType)
+	}
+
+	if err := verifyAllocationState(d); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verifyAllocationState checks the allocation state shared by zones, pods and clusters
+func verifyAllocationState(d *schema.ResourceData) error {
+	if state, ok := d.GetOk("allocation_state"); ok {
+		switch state.(string) {
+		case "Enabled", "Disabled":
+			// These are supported
+		default:
+			return fmt.Errorf(
+				"%q is not a valid allocation state. Valid options are 'Enabled' and 'Disabled'",
+				state.(string))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package cloudstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/cloudstack-go/v2/cloudstack"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCloudStackZone_basic(t *testing.T) {
+	var zone cloudstack.Zone
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackZone_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackZoneExists("cloudstack_zone.foo", &zone),
+					testAccCheckCloudStackZoneAttributes(&zone),
+					resource.TestCheckResourceAttr(
+						"cloudstack_zone.foo", "allocation_state", "Disabled"),
+				),
+			},
+
+			{
+				Config: testAccCloudStackZone_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStackZoneExists("cloudstack_zone.foo", &zone),
+					resource.TestCheckResourceAttr(
+						"cloudstack_zone.foo", "name", "terraform-zone-updated"),
+					resource.TestCheckResourceAttr(
+						"cloudstack_zone.foo", "dns2", "8.8.4.4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudStackZone_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudStackZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudStackZone_basic,
+			},
+
+			{
+				ResourceName:      "cloudstack_zone.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCloudStackZoneExists(
+	n string, zone *cloudstack.Zone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No zone ID is set")
+		}
+
+		cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+		z, _, err := cs.Zone.GetZoneByID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if z.Id != rs.Primary.ID {
+			return fmt.Errorf("Zone not found")
+		}
+
+		*zone = *z
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackZoneAttributes(
+	zone *cloudstack.Zone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		if zone.Name != "terraform-zone" {
+			return fmt.Errorf("Bad name: %s", zone.Name)
+		}
+
+		if zone.Networktype != "Advanced" {
+			return fmt.Errorf("Bad network type: %s", zone.Networktype)
+		}
+
+		if zone.Dns1 != "8.8.8.8" {
+			return fmt.Errorf("Bad DNS: %s", zone.Dns1)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckCloudStackZoneDestroy(s *terraform.State) error {
+	cs := testAccProvider.Meta().(*cloudstack.CloudStackClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "cloudstack_zone" {
+			continue
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No zone ID is set")
+		}
+
+		_, _, err := cs.Zone.GetZoneByID(rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Zone %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCloudStackZone_basic = `
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  internal_dns1 = "10.147.28.6"
+  allocation_state = "Disabled"
+}`
+
+const testAccCloudStackZone_update = `
+resource "cloudstack_zone" "foo" {
+  name = "terraform-zone-updated"
+  network_type = "Advanced"
+  dns1 = "8.8.8.8"
+  dns2 = "8.8.4.4"
+  internal_dns1 = "10.147.28.6"
+  allocation_state = "Disabled"
+}`
@@ -53,6 +53,8 @@ func retrieveID(cs *cloudstack.CloudStackClient, name string, value string, opts
 		id, _, err = cs.DiskOffering.GetDiskOfferingID(value)
 	case "service_offering":
 		id, _, err = cs.ServiceOffering.GetServiceOfferingID(value)
+	case "kubernetes_version":
+		id, _, err = cs.Kubernetes.GetKubernetesSupportedVersionID(value)
 	case "network_offering":
 		id, _, err = cs.NetworkOffering.GetNetworkOfferingID(value)
 	case "project":
@@ -103,6 +105,23 @@ func retrieveTemplateID(cs *cloudstack.CloudStackClient, zoneid, value string) (
 	return id, nil
 }
 
+func retrieveISOID(cs *cloudstack.CloudStackClient, zoneid, value string) (id string, e *retrieveError) {
+	// If the supplied value isn't a ID, try to retrieve the ID ourselves
+	if cloudstack.IsID(value) {
+		return value, nil
+	}
+
+	log.Printf("[DEBUG] Retrieving ID of ISO: %s", value)
+
+	// Ignore count, since an error is returned if there is no exact match
+	id, _, err := cs.ISO.GetIsoID(value, "executable", zoneid)
+	if err != nil {
+		return id, &retrieveError{name: "iso", value: value, err: err}
+	}
+
+	return id, nil
+}
+
 // RetryFunc is the function retried n times
 type RetryFunc func() (interface{}, error)
 
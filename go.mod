@@ -7,6 +7,7 @@ exclude github.com/apache/cloudstack-go/v2 v2.15.0
 exclude github.com/apache/cloudstack-go/v2 v2.14.0
 
 require (
+	github.com/ProtonMail/go-crypto v1.1.0-alpha.2
 	github.com/apache/cloudstack-go/v2 v2.13.2
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.34.0
@@ -14,7 +15,6 @@ require (
 )
 
 require (
-	github.com/ProtonMail/go-crypto v1.1.0-alpha.2 // indirect
 	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
 	github.com/cloudflare/circl v1.3.7 // indirect